@@ -0,0 +1,107 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Fonte bitmap 5x7 com dígitos, letras maiúsculas e a pontuação usada nos eixos.
+// Letras minúsculas são desenhadas como maiúsculas; caracteres sem glifo viram espaço.
+const (
+	glyphWidth   = 5
+	glyphHeight  = 7
+	glyphAdvance = glyphWidth + 1
+)
+
+var glyphs = map[rune][glyphHeight]string{
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'A': {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C': {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D': {"###..", "#..#.", "#...#", "#...#", "#...#", "#..#.", "###.."},
+	'E': {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F': {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G': {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H': {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I': {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J': {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L': {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M': {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S': {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T': {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U': {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V': {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W': {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X': {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z': {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'.': {".....", ".....", ".....", ".....", ".....", ".##..", ".##.."},
+	',': {".....", ".....", ".....", ".....", ".##..", "..#..", ".#..."},
+	':': {".....", ".##..", ".##..", ".....", ".##..", ".##..", "....."},
+	'-': {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	'+': {".....", "..#..", "..#..", "#####", "..#..", "..#..", "....."},
+	'/': {".....", "....#", "...#.", "..#..", ".#...", "#....", "....."},
+	'%': {"##...", "##..#", "...#.", "..#..", ".#...", "#..##", "...##"},
+	'(': {"...#.", "..#..", ".#...", ".#...", ".#...", "..#..", "...#."},
+	')': {".#...", "..#..", "...#.", "...#.", "...#.", "..#..", ".#..."},
+	'[': {".###.", ".#...", ".#...", ".#...", ".#...", ".#...", ".###."},
+	']': {".###.", "...#.", "...#.", "...#.", "...#.", "...#.", ".###."},
+	'_': {".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+	'=': {".....", ".....", "#####", ".....", "#####", ".....", "....."},
+}
+
+// accents troca letras acentuadas pela letra sem acento, que possui glifo
+var accents = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "É", "E", "Ê", "E", "Í", "I",
+	"Ó", "O", "Ô", "O", "Õ", "O", "Ú", "U", "Ç", "C",
+)
+
+// textWidth devolve a largura em pixels do texto na escala informada
+func textWidth(text string, scale int) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return (n*glyphAdvance - 1) * scale
+}
+
+// drawText escreve o texto com o canto superior esquerdo em (x, y)
+func drawText(img *image.RGBA, x, y int, text string, c color.Color, scale int) {
+	for _, r := range accents.Replace(strings.ToUpper(text)) {
+		if glyph, ok := glyphs[r]; ok {
+			for row, line := range glyph {
+				for col, px := range line {
+					if px != '#' {
+						continue
+					}
+					fillRect(img, x+col*scale, y+row*scale, scale, scale, c)
+				}
+			}
+		}
+		x += glyphAdvance * scale
+	}
+}
+
+func fillRect(img *image.RGBA, x, y, w, h int, c color.Color) {
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			img.Set(x+dx, y+dy, c)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"time"
+)
+
+// Point é um valor da série no instante Time
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series é uma linha do gráfico
+type Series struct {
+	Name   string
+	Points []Point
+}
+
+// Options controla título, unidade e tamanho da imagem
+type Options struct {
+	Title  string
+	Unit   string
+	Width  int
+	Height int
+}
+
+var (
+	background = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	axisColor  = color.RGBA{0x33, 0x33, 0x33, 0xFF}
+	gridColor  = color.RGBA{0xE0, 0xE0, 0xE0, 0xFF}
+	textColor  = color.RGBA{0x33, 0x33, 0x33, 0xFF}
+
+	// palette segue a cor verde usada nas planilhas e no email de contadores
+	palette = []color.RGBA{
+		{0x4C, 0xAF, 0x50, 0xFF},
+		{0x21, 0x96, 0xF3, 0xFF},
+		{0xF4, 0x43, 0x36, 0xFF},
+		{0xFF, 0x98, 0x00, 0xFF},
+		{0x9C, 0x27, 0xB0, 0xFF},
+	}
+)
+
+const (
+	marginLeft   = 80
+	marginRight  = 24
+	marginTop    = 44
+	marginBottom = 36
+	legendRow    = 16
+	yTicks       = 5
+	xTicks       = 6
+)
+
+// Line desenha um gráfico de linhas e devolve a imagem em PNG
+func Line(series []Series, opts Options) ([]byte, error) {
+	if opts.Width == 0 {
+		opts.Width = 900
+	}
+	if opts.Height == 0 {
+		opts.Height = 420
+	}
+
+	minT, maxT, minV, maxV, ok := bounds(series)
+	if !ok {
+		return nil, errors.New("nenhum dado para desenhar")
+	}
+
+	legendHeight := 0
+	if len(series) > 1 {
+		legendHeight = len(series) * legendRow
+	}
+	height := opts.Height + legendHeight
+
+	img := image.NewRGBA(image.Rect(0, 0, opts.Width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+
+	plot := image.Rect(marginLeft, marginTop, opts.Width-marginRight, opts.Height-marginBottom)
+
+	// Título
+	drawText(img, (opts.Width-textWidth(opts.Title, 2))/2, 12, opts.Title, textColor, 2)
+
+	// Eixo Y com linhas de grade em valores "redondos"
+	step := niceStep((maxV - minV) / yTicks)
+	lo := math.Floor(minV/step) * step
+	hi := math.Ceil(maxV/step) * step
+	if hi == lo {
+		hi = lo + step
+	}
+	yOf := func(v float64) int {
+		return plot.Max.Y - int(math.Round((v-lo)/(hi-lo)*float64(plot.Dy())))
+	}
+	for v := lo; v <= hi+step/2; v += step {
+		y := yOf(v)
+		hline(img, plot.Min.X, plot.Max.X, y, gridColor)
+		label := formatValue(v, opts.Unit)
+		drawText(img, plot.Min.X-8-textWidth(label, 1), y-glyphHeight/2, label, textColor, 1)
+	}
+
+	// Eixo X com rótulos de data/hora
+	span := maxT.Sub(minT)
+	if span <= 0 {
+		span = time.Minute
+	}
+	xOf := func(t time.Time) int {
+		return plot.Min.X + int(math.Round(float64(t.Sub(minT))/float64(span)*float64(plot.Dx())))
+	}
+	layout := "15:04"
+	if span > 36*time.Hour {
+		layout = "02/01"
+	}
+	for i := 0; i <= xTicks; i++ {
+		t := minT.Add(time.Duration(float64(span) * float64(i) / xTicks))
+		x := xOf(t)
+		vline(img, x, plot.Min.Y, plot.Max.Y, gridColor)
+		label := t.Format(layout)
+		drawText(img, x-textWidth(label, 1)/2, plot.Max.Y+8, label, textColor, 1)
+	}
+
+	hline(img, plot.Min.X, plot.Max.X, plot.Max.Y, axisColor)
+	vline(img, plot.Min.X, plot.Min.Y, plot.Max.Y, axisColor)
+
+	// Séries
+	for i, s := range series {
+		c := palette[i%len(palette)]
+		for j := 1; j < len(s.Points); j++ {
+			a, b := s.Points[j-1], s.Points[j]
+			thickLine(img, xOf(a.Time), yOf(a.Value), xOf(b.Time), yOf(b.Value), c)
+		}
+		if len(s.Points) == 1 {
+			p := s.Points[0]
+			fillRect(img, xOf(p.Time)-2, yOf(p.Value)-2, 5, 5, c)
+		}
+
+		if legendHeight > 0 {
+			y := opts.Height + i*legendRow
+			fillRect(img, marginLeft, y, 12, glyphHeight, c)
+			drawText(img, marginLeft+18, y, s.Name, textColor, 1)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bounds calcula os limites de tempo e valor de todas as séries
+func bounds(series []Series) (minT, maxT time.Time, minV, maxV float64, ok bool) {
+	minV, maxV = math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, p := range s.Points {
+			if !ok || p.Time.Before(minT) {
+				minT = p.Time
+			}
+			if !ok || p.Time.After(maxT) {
+				maxT = p.Time
+			}
+			minV = math.Min(minV, p.Value)
+			maxV = math.Max(maxV, p.Value)
+			ok = true
+		}
+	}
+	if ok && minV == maxV {
+		minV, maxV = minV-1, maxV+1
+	}
+	return
+}
+
+// niceStep arredonda o passo das linhas de grade para 1, 2 ou 5 x 10^n
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(raw)))
+	switch f := raw / exp; {
+	case f <= 1:
+		return exp
+	case f <= 2:
+		return 2 * exp
+	case f <= 5:
+		return 5 * exp
+	default:
+		return 10 * exp
+	}
+}
+
+// formatValue abrevia valores grandes com K, M e G
+func formatValue(v float64, unit string) string {
+	abs := math.Abs(v)
+	var s string
+	switch {
+	case abs >= 1e9:
+		s = fmt.Sprintf("%.1fG", v/1e9)
+	case abs >= 1e6:
+		s = fmt.Sprintf("%.1fM", v/1e6)
+	case abs >= 1e4:
+		s = fmt.Sprintf("%.1fK", v/1e3)
+	case abs >= 100 || v == math.Trunc(v):
+		s = fmt.Sprintf("%.0f", v)
+	default:
+		s = fmt.Sprintf("%.2f", v)
+	}
+	return s + unit
+}
+
+func hline(img *image.RGBA, x0, x1, y int, c color.Color) {
+	for x := x0; x <= x1; x++ {
+		img.Set(x, y, c)
+	}
+}
+
+func vline(img *image.RGBA, x, y0, y1 int, c color.Color) {
+	for y := y0; y <= y1; y++ {
+		img.Set(x, y, c)
+	}
+}
+
+// thickLine desenha uma linha de 2px usando o algoritmo de Bresenham
+func thickLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+
+	for {
+		fillRect(img, x0, y0, 2, 2, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,3 +25,47 @@ func Get(key string, fallback string) string {
 	}
 	return fallback
 }
+
+// GetInt retorna a variável de ambiente convertida para inteiro ou o valor padrão
+// caso ela não exista ou seja inválida
+func GetInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("⚠️  Valor inválido para %s: %s, usando %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// GetDuration retorna a variável de ambiente convertida para time.Duration (ex: "30s", "2m")
+// ou o valor padrão caso ela não exista ou seja inválida
+func GetDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("⚠️  Valor inválido para %s: %s, usando %v", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+// SplitList separa uma lista por vírgulas (ex: "Applications, Impressoras"),
+// ignorando espaços e itens vazios
+func SplitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,147 @@
+package file_handler
+
+import (
+	"LapaTelegramBot/uptime"
+	"fmt"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// GenerateAvailabilitySheet cria uma planilha Excel com a disponibilidade de cada host
+// e uma aba com todas as quedas do período
+func GenerateAvailabilitySheet(title string, reports []uptime.Report) (string, error) {
+	fileName := fmt.Sprintf("disponibilidade_%s.xlsx", time.Now().Format("2006-01-02_15-04-05"))
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	summarySheet := "Disponibilidade"
+	index, err := f.NewSheet(summarySheet)
+	if err != nil {
+		return "", err
+	}
+	outagesSheet := "Quedas"
+	if _, err := f.NewSheet(outagesSheet); err != nil {
+		return "", err
+	}
+	f.SetActiveSheet(index)
+	f.DeleteSheet("Sheet1")
+
+	styles := newSheetStyles(f)
+
+	var period string
+	if len(reports) > 0 {
+		period = fmt.Sprintf("Período: %s a %s", reports[0].From.Format("02/01/2006 15:04"), reports[0].To.Format("02/01/2006 15:04"))
+	}
+
+	// Disponibilidade por host
+	writeSheetHeader(f, styles, summarySheet, title, period,
+		[]string{"Host", "Disponibilidade", "Quedas", "Tempo fora (min)", "MTTR (min)", "Maior queda (min)"})
+	for i, r := range reports {
+		writeSheetRow(f, styles, summarySheet, 5+i, []interface{}{
+			r.Host, r.Availability / 100, len(r.Outages), minutes(r.Downtime), minutes(r.MTTR), minutes(r.Longest.Duration()),
+		}, []cellKind{textCell, percentCell, numberCell, numberCell, numberCell, numberCell})
+	}
+	f.SetColWidth(summarySheet, "A", "A", 35)
+	f.SetColWidth(summarySheet, "B", "F", 18)
+
+	// Quedas de todos os hosts
+	writeSheetHeader(f, styles, outagesSheet, title, period,
+		[]string{"Host", "Início", "Fim", "Duração (min)"})
+	line := 5
+	for _, r := range reports {
+		for _, o := range r.Outages {
+			end := o.End.Format("02/01/2006 15:04")
+			if o.Ongoing {
+				end = "Em andamento"
+			}
+			writeSheetRow(f, styles, outagesSheet, line, []interface{}{
+				o.Host, o.Start.Format("02/01/2006 15:04"), end, minutes(o.Duration()),
+			}, []cellKind{textCell, textCell, textCell, numberCell})
+			line++
+		}
+	}
+	f.SetColWidth(outagesSheet, "A", "A", 35)
+	f.SetColWidth(outagesSheet, "B", "D", 20)
+
+	// Salva a planilha
+	if err := f.SaveAs(fileName); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// cellKind define o estilo de uma coluna dos dados
+type cellKind int
+
+const (
+	textCell cellKind = iota
+	numberCell
+	percentCell
+)
+
+// writeSheetHeader escreve o título, o subtítulo e o cabeçalho na linha 4, congelando-o
+func writeSheetHeader(f *excelize.File, styles sheetStyles, sheet, title, subtitle string, columns []string) {
+	last, _ := excelize.ColumnNumberToName(len(columns))
+
+	f.SetCellValue(sheet, "A1", title)
+	f.SetCellStyle(sheet, "A1", last+"1", styles.title)
+	f.MergeCell(sheet, "A1", last+"1")
+	f.SetRowHeight(sheet, 1, 30)
+
+	generated := fmt.Sprintf("Gerado em: %s", time.Now().Format("02/01/2006 às 15:04:05"))
+	if subtitle != "" {
+		generated += " · " + subtitle
+	}
+	f.SetCellValue(sheet, "A2", generated)
+	f.MergeCell(sheet, "A2", last+"2")
+
+	for i, column := range columns {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 4)
+		f.SetCellValue(sheet, cell, column)
+	}
+	f.SetCellStyle(sheet, "A4", last+"4", styles.header)
+	f.SetRowHeight(sheet, 4, 25)
+
+	f.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		Split:       false,
+		XSplit:      0,
+		YSplit:      4,
+		TopLeftCell: "A5",
+		ActivePane:  "bottomLeft",
+	})
+}
+
+// writeSheetRow escreve uma linha de dados, alternando o fundo das linhas
+func writeSheetRow(f *excelize.File, styles sheetStyles, sheet string, line int, values []interface{}, kinds []cellKind) {
+	alternate := line%2 == 0
+	for i, value := range values {
+		cell, _ := excelize.CoordinatesToCellName(i+1, line)
+		f.SetCellValue(sheet, cell, value)
+
+		style := styles.data
+		switch {
+		case kinds[i] == numberCell && alternate:
+			style = styles.alternateNumber
+		case kinds[i] == numberCell:
+			style = styles.number
+		case kinds[i] == percentCell && alternate:
+			style = styles.alternatePercent
+		case kinds[i] == percentCell:
+			style = styles.percent
+		case alternate:
+			style = styles.alternateRow
+		}
+		f.SetCellStyle(sheet, cell, cell, style)
+	}
+}
+
+func minutes(d time.Duration) int {
+	return int(d.Round(time.Minute) / time.Minute)
+}
@@ -26,127 +26,11 @@ func GenerateSheet(printers []monitor.Printer) (string, error) {
 	f.SetActiveSheet(index)
 	f.DeleteSheet("Sheet1")
 
-	// Define estilos
-	headerStyle, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Bold:   true,
-			Size:   12,
-			Color:  "FFFFFF",
-			Family: "Calibri",
-		},
-		Fill: excelize.Fill{
-			Type:    "pattern",
-			Color:   []string{"4CAF50"},
-			Pattern: 1,
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "center",
-			Vertical:   "center",
-		},
-		Border: []excelize.Border{
-			{Type: "left", Color: "000000", Style: 1},
-			{Type: "right", Color: "000000", Style: 1},
-			{Type: "top", Color: "000000", Style: 1},
-			{Type: "bottom", Color: "000000", Style: 1},
-		},
-	})
-
-	titleStyle, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Bold:   true,
-			Size:   16,
-			Color:  "333333",
-			Family: "Calibri",
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "center",
-			Vertical:   "center",
-		},
-	})
-
-	dataStyle, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Size:   11,
-			Family: "Calibri",
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "left",
-			Vertical:   "center",
-		},
-		Border: []excelize.Border{
-			{Type: "left", Color: "CCCCCC", Style: 1},
-			{Type: "right", Color: "CCCCCC", Style: 1},
-			{Type: "top", Color: "CCCCCC", Style: 1},
-			{Type: "bottom", Color: "CCCCCC", Style: 1},
-		},
-	})
-
-	numberStyle, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Size:   11,
-			Family: "Calibri",
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "right",
-			Vertical:   "center",
-		},
-		Border: []excelize.Border{
-			{Type: "left", Color: "CCCCCC", Style: 1},
-			{Type: "right", Color: "CCCCCC", Style: 1},
-			{Type: "top", Color: "CCCCCC", Style: 1},
-			{Type: "bottom", Color: "CCCCCC", Style: 1},
-		},
-		NumFmt: 3, // Formato de número com separador de milhares
-	})
-
-	alternateRowStyle, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Size:   11,
-			Family: "Calibri",
-		},
-		Fill: excelize.Fill{
-			Type:    "pattern",
-			Color:   []string{"F2F2F2"},
-			Pattern: 1,
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "left",
-			Vertical:   "center",
-		},
-		Border: []excelize.Border{
-			{Type: "left", Color: "CCCCCC", Style: 1},
-			{Type: "right", Color: "CCCCCC", Style: 1},
-			{Type: "top", Color: "CCCCCC", Style: 1},
-			{Type: "bottom", Color: "CCCCCC", Style: 1},
-		},
-	})
-
-	alternateNumberStyle, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Size:   11,
-			Family: "Calibri",
-		},
-		Fill: excelize.Fill{
-			Type:    "pattern",
-			Color:   []string{"F2F2F2"},
-			Pattern: 1,
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "right",
-			Vertical:   "center",
-		},
-		Border: []excelize.Border{
-			{Type: "left", Color: "CCCCCC", Style: 1},
-			{Type: "right", Color: "CCCCCC", Style: 1},
-			{Type: "top", Color: "CCCCCC", Style: 1},
-			{Type: "bottom", Color: "CCCCCC", Style: 1},
-		},
-		NumFmt: 3,
-	})
+	styles := newSheetStyles(f)
 
 	// Título
 	f.SetCellValue(sheetName, "A1", "RELATÓRIO DE CONTADORES DE IMPRESSORAS")
-	f.SetCellStyle(sheetName, "A1", "D1", titleStyle)
+	f.SetCellStyle(sheetName, "A1", "D1", styles.title)
 	f.MergeCell(sheetName, "A1", "D1")
 	f.SetRowHeight(sheetName, 1, 30)
 
@@ -159,7 +43,7 @@ func GenerateSheet(printers []monitor.Printer) (string, error) {
 	f.SetCellValue(sheetName, "B4", "Preto e Branco")
 	f.SetCellValue(sheetName, "C4", "Colorido")
 	f.SetCellValue(sheetName, "D4", "Total")
-	f.SetCellStyle(sheetName, "A4", "D4", headerStyle)
+	f.SetCellStyle(sheetName, "A4", "D4", styles.header)
 	f.SetRowHeight(sheetName, 4, 25)
 
 	// Dados
@@ -169,11 +53,11 @@ func GenerateSheet(printers []monitor.Printer) (string, error) {
 		// Alterna estilo de linha
 		var cellStyle, numStyle int
 		if i%2 == 0 {
-			cellStyle = dataStyle
-			numStyle = numberStyle
+			cellStyle = styles.data
+			numStyle = styles.number
 		} else {
-			cellStyle = alternateRowStyle
-			numStyle = alternateNumberStyle
+			cellStyle = styles.alternateRow
+			numStyle = styles.alternateNumber
 		}
 
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", line), printer.HostData.Host)
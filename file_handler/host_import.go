@@ -0,0 +1,125 @@
+package file_handler
+
+import (
+	"LapaTelegramBot/zabbix"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Nomes aceitos para cada coluna da planilha de importação de hosts
+var hostImportColumns = map[string]string{
+	"host":     "host",
+	"hostname": "host",
+	"nome":     "host",
+	"ip":       "ip",
+	"tipo":     "type",
+	"type":     "type",
+	"grupo":    "group",
+	"group":    "group",
+	"template": "template",
+}
+
+// IsHostImportFile indica se o arquivo tem uma extensão aceita pelo /import_hosts
+func IsHostImportFile(fileName string) bool {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	return ext == ".xlsx" || ext == ".csv"
+}
+
+// ReadHostImport lê a planilha (.xlsx ou .csv) de importação de hosts. A primeira
+// linha deve ter o cabeçalho com as colunas host, ip, tipo, grupo e template.
+func ReadHostImport(path string) ([]zabbix.HostImportRow, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlsx":
+		records, err = readXLSX(path)
+	case ".csv":
+		records, err = readCSV(path)
+	default:
+		return nil, fmt.Errorf("formato não suportado: %s (use .xlsx ou .csv)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("planilha vazia")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		if field, ok := hostImportColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+	for _, required := range []string{"host", "ip", "group"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("cabeçalho sem a coluna obrigatória %q (esperado: host, ip, tipo, grupo, template)", required)
+		}
+	}
+
+	cell := func(record []string, field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []zabbix.HostImportRow
+	for n, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		rows = append(rows, zabbix.HostImportRow{
+			Line:     n + 2,
+			Host:     cell(record, "host"),
+			IP:       cell(record, "ip"),
+			Type:     cell(record, "type"),
+			Group:    cell(record, "group"),
+			Template: cell(record, "template"),
+		})
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("nenhum host encontrado na planilha")
+	}
+	return rows, nil
+}
+
+// readXLSX devolve as linhas da primeira aba da planilha
+func readXLSX(path string) ([][]string, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("planilha sem abas")
+	}
+	return f.GetRows(sheets[0])
+}
+
+// readCSV aceita separador vírgula ou ponto e vírgula (padrão do Excel em pt-BR)
+func readCSV(path string) ([][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimPrefix(string(data), "\ufeff")
+
+	r := csv.NewReader(strings.NewReader(content))
+	firstLine, _, _ := strings.Cut(content, "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	return r.ReadAll()
+}
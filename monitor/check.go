@@ -0,0 +1,87 @@
+package monitor
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"fmt"
+)
+
+// Status é o estado de um resultado de checagem
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusProblem
+	// StatusUnknown indica que não foi possível obter o valor nesta checagem
+	StatusUnknown
+)
+
+// Result é o resultado nomeado de uma checagem, ex: um host, um serviço ou um item
+type Result struct {
+	// ID identifica o resultado entre as checagens (hostid, itemid...)
+	ID   string
+	Name string
+	// Host é o host do resultado, usado para silenciar avisos; vazio se não se aplica
+	Host   string
+	Status Status
+	// Detail complementa o estado, ex: "8 %" para o toner de uma impressora
+	Detail string
+}
+
+// Check é uma checagem executada periodicamente pelos monitores do bot
+type Check interface {
+	// Spec devolve a definição da checagem, gravada para recriá-la com NewCheck
+	Spec() Spec
+	// Describe descreve a checagem para o usuário, ex: "Ping dos hosts"
+	Describe() string
+	Run(ctx context.Context, z *zabbix.Client) ([]Result, error)
+}
+
+// Tipos de checagem disponíveis
+const (
+	KindPing     = "ping"
+	KindProtheus = "protheus"
+	KindToner    = "toner"
+	KindItem     = "item"
+)
+
+// Spec é a definição serializável de uma checagem. Cada tipo usa só os seus campos.
+type Spec struct {
+	Kind string `json:"kind"`
+	// ExcludeGroups são os grupos ignorados pelo ping; vazio usa MONITOR_EXCLUDE_GROUPS
+	ExcludeGroups []string `json:"exclude_groups,omitempty"`
+	// Host, Key e Condition definem a checagem de item
+	Host      string `json:"host,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	// Threshold é o percentual mínimo de toner
+	Threshold float64 `json:"threshold,omitempty"`
+}
+
+// NewCheck recria a checagem a partir da definição. Sem tipo, assume o ping dos
+// hosts, que era a única checagem dos monitores gravados antes dos tipos.
+func NewCheck(spec Spec) (Check, error) {
+	switch spec.Kind {
+	case KindPing, "":
+		return PingCheck{ExcludeGroups: spec.ExcludeGroups}, nil
+	case KindProtheus:
+		return ProtheusCheck{}, nil
+	case KindToner:
+		threshold := spec.Threshold
+		if threshold <= 0 {
+			threshold = DefaultTonerThreshold
+		}
+		return TonerCheck{Threshold: threshold}, nil
+	case KindItem:
+		cond, err := ParseCondition(spec.Condition)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Host == "" || spec.Key == "" {
+			return nil, fmt.Errorf("checagem de item sem host ou key")
+		}
+		return ItemCheck{Host: spec.Host, Key: spec.Key, Condition: cond}, nil
+	default:
+		return nil, fmt.Errorf("tipo de checagem desconhecido: %s", spec.Kind)
+	}
+}
@@ -0,0 +1,87 @@
+package monitor
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// Dependencies liga cada host ao host do qual ele depende para ser alcançado, como
+// o switch ou o link VPN de uma filial. As chaves são os nomes em minúsculas.
+type Dependencies map[string]string
+
+// ReadDependencies lê um arquivo JSON no formato {"host": "host do qual depende"}.
+// Sem o arquivo, devolve um mapa vazio.
+func ReadDependencies(path string) (Dependencies, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Dependencies{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	deps := make(Dependencies, len(raw))
+	for host, parent := range raw {
+		deps.add(host, parent)
+	}
+	return deps, nil
+}
+
+// DependenciesFromTags monta as dependências pela tag dos hosts no Zabbix, cujo
+// valor é o nome do host do qual o host depende, ex: parent=RT-FILIAL-SP
+func DependenciesFromTags(ctx context.Context, z *zabbix.Client, tag string) (Dependencies, error) {
+	values, err := z.GetHostTagValues(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	deps := make(Dependencies, len(values))
+	for host, parent := range values {
+		deps.add(host, parent)
+	}
+	return deps, nil
+}
+
+func (d Dependencies) add(host, parent string) {
+	host, parent = strings.TrimSpace(host), strings.TrimSpace(parent)
+	if host != "" && parent != "" && !strings.EqualFold(host, parent) {
+		d[strings.ToLower(host)] = parent
+	}
+}
+
+// Merge devolve as dependências de d com as de other, que prevalecem
+func (d Dependencies) Merge(other Dependencies) Dependencies {
+	merged := make(Dependencies, len(d)+len(other))
+	for host, parent := range d {
+		merged[host] = parent
+	}
+	for host, parent := range other {
+		merged[host] = parent
+	}
+	return merged
+}
+
+// Root devolve o host mais acima na cadeia de dependências de host que está fora,
+// segundo down. Os hosts que dependem dele são agrupados em um único aviso.
+func (d Dependencies) Root(host string, down func(host string) bool) (string, bool) {
+	var root string
+	visited := map[string]bool{strings.ToLower(host): true}
+	for current := host; ; {
+		parent, ok := d[strings.ToLower(current)]
+		if !ok || visited[strings.ToLower(parent)] {
+			break
+		}
+		visited[strings.ToLower(parent)] = true
+		if down(parent) {
+			root = parent
+		}
+		current = parent
+	}
+	return root, root != ""
+}
@@ -0,0 +1,88 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDependenciesRoot(t *testing.T) {
+	deps := Dependencies{}
+	deps.add("SRV-FILIAL-SP", "SW-FILIAL-SP")
+	deps.add("SW-FILIAL-SP", "RT-FILIAL-SP")
+	deps.add("IMP-FILIAL-SP", "SW-FILIAL-SP")
+
+	downSet := func(hosts ...string) func(string) bool {
+		return func(host string) bool {
+			for _, h := range hosts {
+				if strings.EqualFold(h, host) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	tests := []struct {
+		name string
+		host string
+		down []string
+		want string
+	}{
+		{name: "pai no ar", host: "SRV-FILIAL-SP", down: []string{"SRV-FILIAL-SP"}},
+		{name: "pai fora", host: "srv-filial-sp", down: []string{"SW-FILIAL-SP"}, want: "SW-FILIAL-SP"},
+		{name: "o mais acima fora", host: "SRV-FILIAL-SP", down: []string{"SW-FILIAL-SP", "RT-FILIAL-SP"}, want: "RT-FILIAL-SP"},
+		{name: "avô fora com pai no ar", host: "IMP-FILIAL-SP", down: []string{"RT-FILIAL-SP"}, want: "RT-FILIAL-SP"},
+		{name: "sem dependência", host: "RT-FILIAL-SP", down: []string{"RT-FILIAL-SP"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, ok := deps.Root(tt.host, downSet(tt.down...))
+			if root != tt.want || ok != (tt.want != "") {
+				t.Errorf("Root(%s) = %q, %v; esperado %q", tt.host, root, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependenciesRootCycle(t *testing.T) {
+	deps := Dependencies{}
+	deps.add("A", "B")
+	deps.add("B", "C")
+	deps.add("C", "A")
+
+	root, ok := deps.Root("A", func(string) bool { return true })
+	if !ok || root != "C" {
+		t.Errorf("Root(A) = %q, %v; esperado C, sem voltar a A", root, ok)
+	}
+
+	var empty Dependencies
+	if _, ok := empty.Root("A", func(string) bool { return true }); ok {
+		t.Error("Root em dependências vazias deveria ser falso")
+	}
+}
+
+func TestReadDependencies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dependencies.json")
+	if deps, err := ReadDependencies(path); err != nil || len(deps) != 0 {
+		t.Fatalf("sem arquivo: %v, %v; esperado mapa vazio", deps, err)
+	}
+
+	data := `{"SRV-FILIAL-SP": "RT-FILIAL-SP", "RT-FILIAL-SP": "rt-filial-sp", " ": "X"}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deps, err := ReadDependencies(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps["srv-filial-sp"] != "RT-FILIAL-SP" {
+		t.Errorf("deps = %v, esperado só srv-filial-sp → RT-FILIAL-SP", deps)
+	}
+
+	merged := deps.Merge(Dependencies{"srv-filial-sp": "SW-FILIAL-SP"})
+	if merged["srv-filial-sp"] != "SW-FILIAL-SP" || deps["srv-filial-sp"] != "RT-FILIAL-SP" {
+		t.Errorf("Merge = %v, esperado o valor de other sem alterar o original", merged)
+	}
+}
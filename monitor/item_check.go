@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Condition é a condição de alerta de um item, ex: "> 90", "!= 0" ou "= down"
+type Condition struct {
+	Op    string
+	Value string
+}
+
+// conditionOps são os operadores aceitos, com os de dois caracteres primeiro
+var conditionOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseCondition interpreta a condição no formato "<operador><valor>", com ou sem espaço
+func ParseCondition(text string) (Condition, error) {
+	text = strings.TrimSpace(text)
+	for _, op := range conditionOps {
+		if value, ok := strings.CutPrefix(text, op); ok {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				break
+			}
+			if op != "=" && op != "!=" {
+				if _, err := strconv.ParseFloat(value, 64); err != nil {
+					return Condition{}, fmt.Errorf("condição %q: o operador %s exige um número", text, op)
+				}
+			}
+			return Condition{Op: op, Value: value}, nil
+		}
+	}
+	return Condition{}, fmt.Errorf("condição inválida: %q (use >, >=, <, <=, = ou != seguido do valor)", text)
+}
+
+func (c Condition) String() string {
+	return c.Op + " " + c.Value
+}
+
+// Matches indica se o valor do item satisfaz a condição. Valores numéricos são
+// comparados como números; os demais, como texto (só = e !=).
+func (c Condition) Matches(value string) bool {
+	v, errV := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	t, errT := strconv.ParseFloat(c.Value, 64)
+	if errV != nil || errT != nil {
+		switch c.Op {
+		case "=":
+			return strings.TrimSpace(value) == c.Value
+		case "!=":
+			return strings.TrimSpace(value) != c.Value
+		}
+		return false
+	}
+
+	switch c.Op {
+	case ">":
+		return v > t
+	case ">=":
+		return v >= t
+	case "<":
+		return v < t
+	case "<=":
+		return v <= t
+	case "=":
+		return v == t
+	case "!=":
+		return v != t
+	}
+	return false
+}
+
+// ItemCheck acompanha itens de um host: entram em alerta quando o último valor
+// satisfaz a condição. A key aceita "*" como curinga, ex: "vfs.fs.size[*,pused]".
+type ItemCheck struct {
+	Host      string
+	Key       string
+	Condition Condition
+}
+
+func (c ItemCheck) Spec() Spec {
+	return Spec{Kind: KindItem, Host: c.Host, Key: c.Key, Condition: c.Condition.Op + c.Condition.Value}
+}
+
+func (c ItemCheck) Describe() string {
+	return fmt.Sprintf("%s em %s %s", c.Key, c.Host, c.Condition)
+}
+
+func (c ItemCheck) Run(ctx context.Context, z *zabbix.Client) ([]Result, error) {
+	host, ok, err := z.GetHostByName(ctx, c.Host)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("host %s não encontrado", c.Host)
+	}
+
+	items, err := z.GetItems(ctx, []string{host.Hostid}, c.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(items))
+	for i, item := range items {
+		results[i] = Result{ID: item.Itemid, Name: item.Name, Host: host.Host, Status: StatusOK, Detail: strings.TrimSpace(item.Lastvalue + " " + item.Units)}
+		switch {
+		case item.LastCheck().IsZero():
+			results[i].Status = StatusUnknown
+			results[i].Detail = ""
+		case c.Condition.Matches(item.Lastvalue):
+			results[i].Status = StatusProblem
+		}
+	}
+	return results, nil
+}
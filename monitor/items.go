@@ -0,0 +1,12 @@
+package monitor
+
+// firstError devolve um dos erros de failed quando todos os hosts falharam
+func firstError(failed map[string]error, total int) error {
+	if total == 0 || len(failed) < total {
+		return nil
+	}
+	for _, err := range failed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package monitor
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// itemServer responde item.get com um item icmpping por host pedido e derruba os
+// lotes que contêm o host em failHost
+type itemServer struct {
+	*httptest.Server
+	failHost string
+
+	mu    sync.Mutex
+	calls int
+}
+
+func newItemServer(t *testing.T) *itemServer {
+	t.Helper()
+	s := &itemServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *itemServer) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string          `json:"method"`
+		ID     int64           `json:"id"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	switch req.Method {
+	case "apiinfo.version":
+		resp["result"] = "7.0.0"
+	case "item.get":
+		var params struct {
+			HostIDs []string `json:"hostids"`
+		}
+		json.Unmarshal(req.Params, &params)
+		s.mu.Lock()
+		s.calls++
+		s.mu.Unlock()
+
+		var items []zabbix.Item
+		for _, id := range params.HostIDs {
+			if id == s.failHost {
+				http.Error(w, "indisponível", http.StatusServiceUnavailable)
+				return
+			}
+			items = append(items, zabbix.Item{Itemid: "i" + id, Hostid: id, Key: "icmpping", Lastvalue: "1", Prevvalue: "1"})
+		}
+		resp["result"] = items
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *itemServer) client() *zabbix.Client {
+	return &zabbix.Client{URL: s.URL, Token: "token"}
+}
+
+func hostIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = strconv.Itoa(10000 + i)
+	}
+	return ids
+}
+
+func TestFillStatusItemValuesMarksFailedHosts(t *testing.T) {
+	s := newItemServer(t)
+	ids := hostIDs(150)
+	s.failHost = ids[len(ids)-1]
+
+	hosts := make([]zabbix.Host, len(ids))
+	for i, id := range ids {
+		hosts[i] = zabbix.Host{Hostid: id, Host: "SRV" + id}
+	}
+	if err := fillStatusItemValues(context.Background(), s.client(), hosts); err != nil {
+		t.Fatal(err)
+	}
+
+	var ok, failed int
+	for _, h := range hosts {
+		switch {
+		case h.Error:
+			failed++
+		case h.Lastvalue == "1":
+			ok++
+		}
+	}
+	if ok != 100 || failed != 50 {
+		t.Errorf("%d hosts com valor e %d com erro, esperado 100 e 50", ok, failed)
+	}
+}
+
+func TestFillStatusItemValuesFailsWhenAllFail(t *testing.T) {
+	s := newItemServer(t)
+	s.failHost = "10000"
+
+	hosts := []zabbix.Host{{Hostid: "10000", Host: "SRV01"}}
+	if err := fillStatusItemValues(context.Background(), s.client(), hosts); err == nil {
+		t.Error("esperado erro com todos os lotes falhando")
+	}
+}
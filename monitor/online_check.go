@@ -2,98 +2,177 @@ package monitor
 
 import (
 	"LapaTelegramBot/zabbix"
-	"encoding/json"
+	"context"
 	"fmt"
-	"sync"
+	"strings"
 )
 
-func CheckHostsStatus(z *zabbix.Client) ([]string, error) {
-	hosts, err := z.GetHosts()
+func CheckHostsStatus(ctx context.Context, z *zabbix.Client) ([]string, error) {
+	statuses, err := HostStatuses(ctx, z)
 	if err != nil {
 		return nil, err
 	}
+	return formatStatus(statuses), nil
+}
 
-	var wg sync.WaitGroup
+// HostStatuses devolve o estado de ping de todos os hosts ativos
+func HostStatuses(ctx context.Context, z *zabbix.Client) ([]HostStatus, error) {
+	hosts, err := z.GetHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return hostStatuses(ctx, z, hosts)
+}
 
-	for i := range hosts {
-		wg.Add(1)
-		go getStatusItemValue(z, &hosts[i], &wg)
+// CheckHostsStatusExcludingGroups checa hosts, mas exclui hosts que pertençam
+// aos grupos listados em excludeNames e hosts em manutenção ativa no Zabbix.
+func CheckHostsStatusExcludingGroups(ctx context.Context, z *zabbix.Client, excludeNames []string) ([]string, error) {
+	statuses, err := HostStatusesExcludingGroups(ctx, z, excludeNames)
+	if err != nil {
+		return nil, err
 	}
+	return formatStatus(statuses), nil
+}
 
-	func() {
-		wg.Wait()
-	}()
+// HostStatus é o resultado da checagem de ping de um host
+type HostStatus struct {
+	Hostid string
+	Host   string
+	Online bool
+	// Unknown indica que a consulta do item falhou e o estado do host é desconhecido
+	Unknown bool
+}
 
-	var onlineHosts []string
-	var offlineHosts []string
-	for _, host := range hosts {
-		if host.Lastvalue == "1" && host.Prevvalue == "1" {
-			onlineHosts = append(onlineHosts, fmt.Sprintf("✅ %s", host.Host))
-		} else {
-			offlineHosts = append(offlineHosts, fmt.Sprintf("❌ %s", host.Host))
-		}
+// HostStatusesExcludingGroups devolve o estado de cada host monitorado, com as mesmas
+// exclusões de CheckHostsStatusExcludingGroups. Usado pelo monitor, que acompanha
+// as mudanças de estado de cada host entre as checagens. A manutenção é consultada
+// na hora, e não no inventário, para respeitar uma manutenção recém-criada.
+func HostStatusesExcludingGroups(ctx context.Context, z *zabbix.Client, excludeNames []string) ([]HostStatus, error) {
+	hosts, err := z.GetHostsExcludingGroups(ctx, excludeNames)
+	if err != nil {
+		return nil, err
 	}
-	return append(onlineHosts, offlineHosts...), nil
-}
 
-// CheckHostsStatusExcludingGroups checa hosts, mas exclui hosts que pertençam
-// aos grupos listados em excludeNames.
-func CheckHostsStatusExcludingGroups(z *zabbix.Client, excludeNames []string) ([]string, error) {
-	hosts, err := z.GetHostsExcludingGroups(excludeNames)
+	maintenance, err := z.HostsInMaintenance(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var wg sync.WaitGroup
+	var monitored []zabbix.Host
+	for _, h := range hosts {
+		if !maintenance[h.Hostid] {
+			monitored = append(monitored, h)
+		}
+	}
+
+	return hostStatuses(ctx, z, monitored)
+}
 
-	for i := range hosts {
-		wg.Add(1)
-		go getStatusItemValue(z, &hosts[i], &wg)
+func hostStatuses(ctx context.Context, z *zabbix.Client, hosts []zabbix.Host) ([]HostStatus, error) {
+	if err := fillStatusItemValues(ctx, z, hosts); err != nil {
+		return nil, err
 	}
 
-	func() {
-		wg.Wait()
-	}()
+	statuses := make([]HostStatus, len(hosts))
+	for i, host := range hosts {
+		statuses[i] = HostStatus{
+			Hostid:  host.Hostid,
+			Host:    host.Host,
+			Online:  host.Lastvalue == "1" && host.Prevvalue == "1",
+			Unknown: host.Error,
+		}
+	}
+	return statuses, nil
+}
 
+// formatStatus lista os hosts online e depois os offline; hosts sem dados contam como offline
+func formatStatus(statuses []HostStatus) []string {
 	var onlineHosts []string
 	var offlineHosts []string
-	for _, host := range hosts {
-		if host.Lastvalue == "1" && host.Prevvalue == "1" {
+	for _, host := range statuses {
+		if host.Online {
 			onlineHosts = append(onlineHosts, fmt.Sprintf("✅ %s", host.Host))
 		} else {
 			offlineHosts = append(offlineHosts, fmt.Sprintf("❌ %s", host.Host))
 		}
 	}
-	return append(onlineHosts, offlineHosts...), nil
+	return append(onlineHosts, offlineHosts...)
 }
 
-func getStatusItemValue(z *zabbix.Client, host *zabbix.Host, wg *sync.WaitGroup) {
-	defer wg.Done()
+// fillStatusItemValues preenche Lastvalue/Prevvalue com o item icmpping de cada host,
+// marcando Error nos hosts cuja consulta falhou. Só retorna erro se todos falharem.
+func fillStatusItemValues(ctx context.Context, z *zabbix.Client, hosts []zabbix.Host) error {
+	ids := make([]string, len(hosts))
+	for i, h := range hosts {
+		ids[i] = h.Hostid
+	}
 
-	params := map[string]interface{}{
-		"output":  "extend",
-		"hostids": host.Hostid,
-		"search": map[string]string{
-			"key_": "icmpping",
-		},
+	items, failed := z.GetItemsByHost(ctx, ids, "icmpping")
+	if err := firstError(failed, len(hosts)); err != nil {
+		return err
 	}
 
-	resp, err := z.Call("item.get", params)
-	if err != nil {
-		host.Error = true
-		return
+	for i := range hosts {
+		host := &hosts[i]
+		if _, ok := failed[host.Hostid]; ok {
+			host.Error = true
+			continue
+		}
+
+		if item, ok := pingItem(items[host.Hostid]); ok {
+			host.Lastvalue = item.Lastvalue
+			host.Prevvalue = item.Prevvalue
+		}
 	}
+	return nil
+}
 
-	var items []struct {
-		Itemid    string `json:"itemid"`
-		Lastvalue string `json:"lastvalue"`
-		Prevvalue string `json:"prevvalue"`
+// pingItem escolhe o item icmpping do host, ignorando icmppingloss e icmppingsec
+// que também casam com a busca. Um host só com esses itens fica sem item de ping.
+func pingItem(items []zabbix.Item) (zabbix.Item, bool) {
+	for _, item := range items {
+		if item.Key == "icmpping" || strings.HasPrefix(item.Key, "icmpping[") {
+			return item, true
+		}
 	}
+	return zabbix.Item{}, false
+}
 
-	json.Unmarshal(resp, &items)
+// PingCheck acompanha o item icmpping dos hosts ativos, fora de manutenção e dos
+// grupos excluídos
+type PingCheck struct {
+	// ExcludeGroups são os grupos ignorados; vazio usa os grupos do servidor
+	ExcludeGroups []string
+}
+
+func (c PingCheck) Spec() Spec {
+	return Spec{Kind: KindPing, ExcludeGroups: c.ExcludeGroups}
+}
+
+func (c PingCheck) Describe() string {
+	return "Ping dos hosts"
+}
+
+func (c PingCheck) Run(ctx context.Context, z *zabbix.Client) ([]Result, error) {
+	exclude := c.ExcludeGroups
+	if len(exclude) == 0 {
+		exclude = z.Groups.MonitorExclude
+	}
+
+	statuses, err := HostStatusesExcludingGroups(ctx, z, exclude)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(items) > 0 {
-		host.Lastvalue = items[0].Lastvalue
-		host.Prevvalue = items[0].Prevvalue
+	results := make([]Result, len(statuses))
+	for i, s := range statuses {
+		results[i] = Result{ID: s.Hostid, Name: s.Host, Host: s.Host, Status: StatusProblem}
+		switch {
+		case s.Unknown:
+			results[i].Status = StatusUnknown
+		case s.Online:
+			results[i].Status = StatusOK
+		}
 	}
+	return results, nil
 }
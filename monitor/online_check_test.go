@@ -0,0 +1,68 @@
+package monitor_test
+
+import (
+	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/zabbix"
+	"LapaTelegramBot/zabbix/zabbixtest"
+	"context"
+	"testing"
+	"time"
+)
+
+// addPingHost adiciona um host com o item icmpping nos dois últimos valores informados
+func addPingHost(s *zabbixtest.Server, name, prev, last string) string {
+	id := s.AddHost(zabbixtest.Host{Host: name})
+	s.AddItem(zabbixtest.Item{Item: zabbix.Item{Hostid: id, Name: "ICMP ping", Key: "icmpping", Prevvalue: prev, Lastvalue: last}})
+	return id
+}
+
+func TestHostStatusesReadsMaintenanceLive(t *testing.T) {
+	s := zabbixtest.NewServer()
+	defer s.Close()
+	srv01 := addPingHost(s, "SRV01", "1", "1")
+	addPingHost(s, "SRV02", "0", "0")
+
+	c := s.Client()
+	c.Inventory().Interval = time.Hour
+	if err := c.RefreshInventory(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Manutenção criada fora do bot: o inventário ainda mostra o host fora dela
+	s.AddMaintenance(zabbixtest.Maintenance{
+		Name:        "Janela",
+		ActiveSince: time.Now().Add(-time.Minute),
+		ActiveTill:  time.Now().Add(time.Hour),
+		HostIDs:     []string{srv01},
+	})
+
+	statuses, err := monitor.HostStatusesExcludingGroups(context.Background(), c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Host != "SRV02" || statuses[0].Online {
+		t.Errorf("statuses = %+v, esperado só SRV02 offline", statuses)
+	}
+}
+
+func TestHostStatusesIgnoresOtherPingItems(t *testing.T) {
+	s := zabbixtest.NewServer()
+	defer s.Close()
+	addPingHost(s, "SRV01", "1", "1")
+	id := s.AddHost(zabbixtest.Host{Host: "SRV02"})
+	s.AddItem(zabbixtest.Item{Item: zabbix.Item{Hostid: id, Name: "ICMP response time", Key: "icmppingsec", Prevvalue: "1", Lastvalue: "1"}})
+	s.AddItem(zabbixtest.Item{Item: zabbix.Item{Hostid: id, Name: "ICMP loss", Key: "icmppingloss", Prevvalue: "1", Lastvalue: "1"}})
+
+	statuses, err := monitor.HostStatusesExcludingGroups(context.Background(), s.Client(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	online := make(map[string]bool)
+	for _, st := range statuses {
+		online[st.Host] = st.Online
+	}
+	// SRV02 não tem icmpping: os valores de icmppingsec e icmppingloss não contam como ping
+	if !online["SRV01"] || online["SRV02"] {
+		t.Errorf("statuses = %+v, esperado SRV01 online e SRV02 sem item de ping", statuses)
+	}
+}
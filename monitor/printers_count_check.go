@@ -2,9 +2,8 @@ package monitor
 
 import (
 	"LapaTelegramBot/zabbix"
-	"encoding/json"
+	"context"
 	"strconv"
-	"sync"
 )
 
 type Printer struct {
@@ -14,69 +13,48 @@ type Printer struct {
 	TotalCounter int64
 }
 
-func GetPrintersCounter(z *zabbix.Client) ([]Printer, error) {
-	hosts, err := z.GetPrinters()
+func GetPrintersCounter(ctx context.Context, z *zabbix.Client) ([]Printer, error) {
+	hosts, err := z.GetPrinters(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	printers := make([]Printer, len(hosts))
+	ids := make([]string, len(hosts))
 	for i, host := range hosts {
 		printers[i] = Printer{HostData: host, BlackCounter: 0, ColorCounter: 0, TotalCounter: 0}
+		ids[i] = host.Hostid
 	}
 
-	var wg sync.WaitGroup
+	items, failed := z.GetItemsByHost(ctx, ids, "contador")
+	if err := firstError(failed, len(hosts)); err != nil {
+		return nil, err
+	}
 
 	for i := range printers {
-		wg.Add(1)
-		go getCounterItemValue(z, &printers[i], &wg)
+		printer := &printers[i]
+		if _, ok := failed[printer.HostData.Hostid]; ok {
+			printer.HostData.Error = true
+			continue
+		}
+		setCounterValues(printer, items[printer.HostData.Hostid])
 	}
 
-	func() {
-		wg.Wait()
-	}()
-
 	return printers, nil
 }
 
-func getCounterItemValue(z *zabbix.Client, printer *Printer, wg *sync.WaitGroup) {
-	defer wg.Done()
-
-	params := map[string]interface{}{
-		"output":  "extend",
-		"hostids": printer.HostData.Hostid,
-		"search": map[string]string{
-			"key_": "contador",
-		},
-	}
-
-	resp, err := z.Call("item.get", params)
-	if err != nil {
-		printer.HostData.Error = true
-		return
-	}
-
-	var items []struct {
-		Itemid    string `json:"itemid"`
-		Key       string `json:"key_"`
-		Lastvalue string `json:"lastvalue"`
-	}
-
-	json.Unmarshal(resp, &items)
-
-	if len(items) > 0 {
-		for _, item := range items {
-			switch item.Key {
-			case "contador.colorido":
-				c, _ := strconv.Atoi(item.Lastvalue)
-				printer.ColorCounter = int64(c)
-			case "contador.peb":
-				c, _ := strconv.Atoi(item.Lastvalue)
-				printer.BlackCounter = int64(c)
-			case "contador.total":
-				c, _ := strconv.Atoi(item.Lastvalue)
-				printer.TotalCounter = int64(c)
-			}
+func setCounterValues(printer *Printer, items []zabbix.Item) {
+	for _, item := range items {
+		switch item.Key {
+		case "contador.colorido":
+			c, _ := strconv.Atoi(item.Lastvalue)
+			printer.ColorCounter = int64(c)
+		case "contador.peb":
+			c, _ := strconv.Atoi(item.Lastvalue)
+			printer.BlackCounter = int64(c)
+		case "contador.total":
+			c, _ := strconv.Atoi(item.Lastvalue)
+			printer.TotalCounter = int64(c)
 		}
 	}
 }
@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// DefaultTonerThreshold é o percentual de toner abaixo do qual a impressora entra em alerta
+const DefaultTonerThreshold = 10
+
+// tonerKey é o trecho da key dos itens de nível de toner (em %) das impressoras
+const tonerKey = "toner"
+
+// TonerCheck acompanha o nível de toner das impressoras do grupo de impressoras
+type TonerCheck struct {
+	// Threshold é o percentual mínimo de toner
+	Threshold float64
+}
+
+func (c TonerCheck) Spec() Spec {
+	return Spec{Kind: KindToner, Threshold: c.Threshold}
+}
+
+func (c TonerCheck) Describe() string {
+	return fmt.Sprintf("Toner das impressoras (abaixo de %g%%)", c.Threshold)
+}
+
+func (c TonerCheck) Run(ctx context.Context, z *zabbix.Client) ([]Result, error) {
+	hosts, err := z.GetPrinters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(hosts))
+	names := make(map[string]string, len(hosts))
+	for i, host := range hosts {
+		ids[i] = host.Hostid
+		names[host.Hostid] = host.Host
+	}
+
+	items, failed := z.GetItemsByHost(ctx, ids, tonerKey)
+	if err := firstError(failed, len(hosts)); err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, host := range hosts {
+		// Sem os itens do lote que falhou, a impressora fica sem dados nesta checagem.
+		// O monitor mantém o estado anterior dos itens dela.
+		if _, ok := failed[host.Hostid]; ok {
+			results = append(results, Result{ID: "host:" + host.Hostid, Name: host.Host, Host: host.Host, Status: StatusUnknown})
+			continue
+		}
+		for _, item := range items[host.Hostid] {
+			r := Result{ID: item.Itemid, Name: fmt.Sprintf("%s: %s", names[item.Hostid], item.Name), Host: names[item.Hostid], Status: StatusOK}
+
+			level, err := strconv.ParseFloat(item.Lastvalue, 64)
+			switch {
+			case err != nil || item.LastCheck().IsZero():
+				r.Status = StatusUnknown
+			case level < c.Threshold:
+				r.Status = StatusProblem
+			}
+			if err == nil {
+				r.Detail = fmt.Sprintf("%g%%", level)
+			}
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,69 @@
+package monitor_test
+
+import (
+	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/zabbix"
+	"LapaTelegramBot/zabbix/zabbixtest"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failItems derruba as chamadas item.get do lote que contém o host
+type failItems struct {
+	hostID string
+}
+
+func (f failItems) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if bytes.Contains(body, []byte(`"item.get"`)) && bytes.Contains(body, []byte(`"`+f.hostID+`"`)) {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Status:     "503 Service Unavailable",
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestTonerCheckReportsFailedHostsAsUnknown(t *testing.T) {
+	s := zabbixtest.NewServer()
+	defer s.Close()
+	group := s.AddGroup("Impressoras")
+
+	// 150 impressoras: dois lotes de item.get, o segundo falha
+	clock := strconv.FormatInt(time.Now().Unix(), 10)
+	var last string
+	for i := 0; i < 150; i++ {
+		last = s.AddHost(zabbixtest.Host{Host: "IMP" + strconv.Itoa(i), GroupIDs: []string{group}})
+		s.AddItem(zabbixtest.Item{Item: zabbix.Item{Hostid: last, Name: "Toner preto", Key: "toner.black", Lastvalue: "50", Lastclock: clock}})
+	}
+
+	c := s.Client()
+	c.Groups.Printers = "Impressoras"
+	c.HTTPClient = &http.Client{Transport: failItems{hostID: last}}
+
+	results, err := monitor.TonerCheck{Threshold: 10}.Run(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := make(map[monitor.Status]int)
+	for _, r := range results {
+		statuses[r.Status]++
+	}
+	if statuses[monitor.StatusOK] != 100 || statuses[monitor.StatusUnknown] != 50 {
+		t.Errorf("resultados por estado = %v, esperado 100 OK e 50 sem dados", statuses)
+	}
+}
@@ -0,0 +1,36 @@
+package monitor
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+)
+
+// ProtheusCheck acompanha os serviços do Protheus, como o /protheus_status
+type ProtheusCheck struct{}
+
+func (c ProtheusCheck) Spec() Spec {
+	return Spec{Kind: KindProtheus}
+}
+
+func (c ProtheusCheck) Describe() string {
+	return "Serviços do Protheus"
+}
+
+func (c ProtheusCheck) Run(ctx context.Context, z *zabbix.Client) ([]Result, error) {
+	services, err := z.GetProtheusServiceStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(services))
+	for i, s := range services {
+		results[i] = Result{ID: s.Itemid, Name: s.ServiceName(), Status: StatusProblem}
+		switch {
+		case s.Lastvalue == "":
+			results[i].Status = StatusUnknown
+		case s.Running():
+			results[i].Status = StatusOK
+		}
+	}
+	return results, nil
+}
@@ -2,28 +2,69 @@ package bot
 
 import (
 	"LapaTelegramBot/mailer"
+	"LapaTelegramBot/monitor"
 	"LapaTelegramBot/schedule"
+	"LapaTelegramBot/uptime"
 	"LapaTelegramBot/zabbix"
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 type Bot struct {
 	API             *tgbotapi.BotAPI
-	Zabbix          *zabbix.Client
+	Zabbix          *zabbix.Client // servidor principal, usado quando o chat não escolheu outro
+	Servers         *zabbix.Servers
 	Mailer          *mailer.Client
 	ScheduleStore   *schedule.Storage
 	ScheduleManager *schedule.Manager
-	Commands        map[string]func(tgbotapi.Update)
+	Commands        map[string]commandHandler
 	AllowedChats    map[int64]bool
-	Monitors        map[int64]*Monitor
+	AdminChats      map[int64]bool
+	Monitors        map[int64]*Monitor // monitores em execução, pelo ID do monitor
+
+	// monitorStore grava os monitores para retomá-los após um reinício e
+	// muteStore os hosts silenciados em cada chat
+	monitorStore *monitorStore
+	muteStore    *muteStore
+	// quietStore grava as janelas de avisos de cada chat
+	quietStore *quietStore
+	// fileDependencies são as dependências entre hosts de dependencies.json
+	fileDependencies monitor.Dependencies
+	// uptimeStore guarda o histórico de quedas dos hosts (/uptime e /availability)
+	uptimeStore *uptime.Store
+	// escalation são os níveis de escalonamento dos monitores (MONITOR_ESCALATION)
+	escalation []escalationLevel
+
+	// mu protege o estado de conversas usado por handlers e callbacks
+	mu           sync.Mutex
+	problemViews map[int64]*problemView
+	pending      map[int64]pendingInput
+	addHostFlows map[int64]*addHostFlow
+	hostImports  map[int64]*hostImport
+
+	// chatServers é o servidor Zabbix padrão de cada chat (/zbx_use)
+	chatServers map[int64]string
+
+	// alertMessages guarda, por evento, a mensagem de alerta enviada a cada chat.
+	// Fica só em memória e é descartada após alertTTL (WEBHOOK_ALERT_TTL).
+	alertMessages map[string]alertMessage
+	alertTTL      time.Duration
+	webhookChats  map[int64]bool
+
+	Callbacks map[string]callbackHandler
 }
 
+// zabbixTimeout limita o tempo que um comando aguarda as respostas do Zabbix
+const zabbixTimeout = 2 * time.Minute
+
 func StartBot() {
 	token := os.Getenv("TELEGRAM_API_TOKEN")
 	chatsIds := os.Getenv("TELEGRAM_ALLOWED_CHAT_ID")
@@ -31,26 +72,83 @@ func StartBot() {
 	allowedChatID := strings.Split(chatsIds, ",")
 	allowed := loadAllowedChats(allowedChatID)
 
+	// Sem TELEGRAM_ADMIN_CHAT_ID, todos os chats autorizados podem usar comandos administrativos
+	admins := loadAllowedChats(strings.Split(os.Getenv("TELEGRAM_ADMIN_CHAT_ID"), ","))
+	if len(admins) == 0 {
+		admins = allowed
+	}
+
 	api, err := tgbotapi.NewBotAPI(token)
 	if err != nil {
 		log.Panic(err)
 	}
 
+	servers := loadServers()
+
 	bot := &Bot{
 		API:          api,
-		Zabbix:       zabbix.NewClient(),
+		Zabbix:       servers.Default(),
+		Servers:      servers,
 		Mailer:       mailer.NewClient(),
 		AllowedChats: allowed,
+		AdminChats:   admins,
 		Monitors:     make(map[int64]*Monitor),
+		problemViews: make(map[int64]*problemView),
+		pending:      make(map[int64]pendingInput),
+		addHostFlows: make(map[int64]*addHostFlow),
+		hostImports:  make(map[int64]*hostImport),
+
+		alertMessages: make(map[string]alertMessage),
+		escalation:    loadEscalation(),
 	}
 
+	bot.initServers()
+	bot.initZabbix()
+	bot.startInventory()
 	bot.initCommands()
+	bot.initCallbacks()
 	bot.initSchedule()
+	bot.initMutes()
+	bot.initQuietHours()
+	bot.initUptime()
+	bot.initDependencies()
+	bot.initMonitors()
+	bot.initWebhook()
 
 	log.Println("Bot iniciado como:", bot.API.Self.UserName)
 	bot.Start()
 }
 
+// loadServers carrega os perfis de ZABBIX_SERVERS ou, com ZABBIX_DEMO=true em um
+// binário compilado com -tags demo, o Zabbix fake do modo demonstração (ver demo.go)
+func loadServers() *zabbix.Servers {
+	if os.Getenv("ZABBIX_DEMO") == "true" {
+		if servers, ok := demoServers(); ok {
+			return servers
+		}
+		log.Println("⚠️  ZABBIX_DEMO ignorado: o modo demonstração exige compilar com -tags demo")
+	}
+	return zabbix.LoadServers()
+}
+
+// initZabbix resolve os grupos configurados para que as consultas usem o cache.
+// Uma falha aqui não impede o bot de subir: os grupos são resolvidos sob demanda.
+func (b *Bot) initZabbix() {
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	for _, z := range b.Servers.All() {
+		if err := z.ResolveGroups(ctx); err != nil {
+			log.Printf("⚠️  Erro ao resolver grupos do Zabbix %s: %v", z.Name, err)
+		}
+	}
+}
+
+// zabbixContext cria o contexto usado pelos handlers nas consultas ao Zabbix
+func (b *Bot) zabbixContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), zabbixTimeout)
+}
+
 func (b *Bot) initSchedule() {
 	b.ScheduleStore = schedule.NewStorage()
 	b.ScheduleManager = schedule.NewManager()
@@ -64,22 +162,46 @@ func (b *Bot) initSchedule() {
 }
 
 func (b *Bot) initCommands() {
-	b.Commands = map[string]func(tgbotapi.Update){
-		"status_check":      b.handleStatusCheck,
-		"status_monitor":    b.handleStatusMonitor,
-		"protheus_status":   b.handleProtheusStatus,
-		"listip":            b.handleListIp,
-		"ping":              b.handlePing,
-		"services":          b.handleRemoteServices,
-		"list_services":     b.handleListServices,
-		"printers_counter":  b.handlePrinterCounter,
-		"schedule_add":      b.handleScheduleAdd,
-		"schedule_remove":   b.handleScheduleRemove,
-		"schedule_list":     b.handleScheduleList,
-		"schedule_help":     b.handleScheduleHelp,
-		"restart_win":       b.handleRestartWindowsHost,
-		"shutdown_win":      b.handleShutdownWindowsHost,
-		"send_mail_counter": b.handleSendMailCounter,
+	b.Commands = map[string]commandHandler{
+		"status_check":       b.handleStatusCheck,
+		"status_monitor":     b.handleStatusMonitor,
+		"monitor_add":        b.handleMonitorAdd,
+		"monitor_list":       withoutZabbix(b.handleMonitorList),
+		"monitor_stop":       withoutZabbix(b.handleMonitorStop),
+		"mute":               b.handleMute,
+		"unmute":             withoutZabbix(b.handleUnmute),
+		"mutes":              withoutZabbix(b.handleMutes),
+		"notify_window":      withoutZabbix(b.handleNotifyWindow),
+		"uptime":             b.handleUptime,
+		"availability":       b.handleAvailability,
+		"problems":           b.handleProblems,
+		"history":            b.handleHistory,
+		"latest":             b.handleLatest,
+		"item":               b.handleItem,
+		"maintenance_add":    b.handleMaintenanceAdd,
+		"maintenance_list":   b.handleMaintenanceList,
+		"maintenance_remove": b.handleMaintenanceRemove,
+		"zbx_use":            withoutZabbix(b.handleZabbixUse),
+		"zbx_enable":         b.handleZabbixEnableHost,
+		"zbx_disable":        b.handleZabbixDisableHost,
+		"zbx_add_host":       b.handleZabbixAddHost,
+		"zbx_refresh":        b.handleZabbixRefresh,
+		"import_hosts":       withoutZabbix(b.handleImportHosts),
+		"protheus_status":    b.handleProtheusStatus,
+		"listip":             b.handleListIp,
+		"host":               b.handleHost,
+		"ping":               withoutZabbix(b.handlePing),
+		"services":           withoutZabbix(b.handleRemoteServices),
+		"list_services":      withoutZabbix(b.handleListServices),
+		"zbx_script":         b.handleZabbixScript,
+		"printers_counter":   b.handlePrinterCounter,
+		"schedule_add":       withoutZabbix(b.handleScheduleAdd),
+		"schedule_remove":    withoutZabbix(b.handleScheduleRemove),
+		"schedule_list":      withoutZabbix(b.handleScheduleList),
+		"schedule_help":      withoutZabbix(b.handleScheduleHelp),
+		"restart_win":        withoutZabbix(b.handleRestartWindowsHost),
+		"shutdown_win":       withoutZabbix(b.handleShutdownWindowsHost),
+		"send_mail_counter":  b.handleSendMailCounter,
 	}
 }
 
@@ -92,52 +214,7 @@ func (b *Bot) Start() {
 	for update := range updates {
 		// Processa callback queries (inline buttons)
 		if update.CallbackQuery != nil {
-			data := update.CallbackQuery.Data
-			// formato esperado: monitor:action:chatID
-			parts := strings.Split(data, ":")
-			if len(parts) >= 3 && parts[0] == "monitor" {
-				action := parts[1]
-				chatID := update.CallbackQuery.Message.Chat.ID
-
-				m, ok := b.Monitors[chatID]
-				if !ok {
-					// responde callback e envia mensagem de erro
-					answer := tgbotapi.NewCallback(update.CallbackQuery.ID, "Monitor não encontrado.")
-					b.API.Request(answer)
-					b.API.Send(tgbotapi.NewMessage(chatID, "Monitor não encontrado para este chat."))
-					continue
-				}
-
-				switch action {
-				case "stop":
-					// Remove os botões da última mensagem, se existir
-					if m.lastMsgID != 0 {
-						edit := tgbotapi.NewEditMessageReplyMarkup(chatID, m.lastMsgID, tgbotapi.InlineKeyboardMarkup{})
-						b.API.Send(edit)
-						m.lastMsgID = 0
-					}
-					// sinaliza parada sem fechar o channel diretamente
-					select {
-					case m.stopCh <- struct{}{}:
-					default:
-					}
-					delete(b.Monitors, chatID)
-					// responde callback e envia mensagem ao chat
-					answer := tgbotapi.NewCallback(update.CallbackQuery.ID, "Monitor parado.")
-					b.API.Request(answer)
-					b.API.Send(tgbotapi.NewMessage(chatID, "Monitor parado."))
-				case "increase":
-					// marca que o monitor está aguardando novo intervalo via mensagem
-					m.waitingInterval = true
-					answer := tgbotapi.NewCallback(update.CallbackQuery.ID, "Peça enviada.")
-					b.API.Request(answer)
-					b.API.Send(tgbotapi.NewMessage(chatID, "Envie o novo intervalo em minutos como mensagem nesta conversa."))
-				default:
-					answer := tgbotapi.NewCallback(update.CallbackQuery.ID, "Ação desconhecida.")
-					b.API.Request(answer)
-					b.API.Send(tgbotapi.NewMessage(chatID, "Ação desconhecida."))
-				}
-			}
+			b.handleCallback(update.CallbackQuery)
 			continue
 		}
 		logUpdate(update)
@@ -168,20 +245,11 @@ func (b *Bot) Start() {
 				continue
 			}
 
-			// Se existe um monitor aguardando novo intervalo, trata essa mensagem como novo intervalo
-			if m, ok := b.Monitors[update.Message.Chat.ID]; ok && m.waitingInterval {
-				text := strings.TrimSpace(update.Message.Text)
-				minutes, err := strconv.Atoi(text)
-				if err != nil || minutes <= 0 {
-					b.API.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Intervalo inválido. Informe um número inteiro de minutos."))
-					continue
-				}
-				// envia novo intervalo para o monitor
-				m.waitingInterval = false
-				m.updateInterval <- minutes
-				b.API.Send(tgbotapi.NewMessage(update.Message.Chat.ID, fmt.Sprintf("Intervalo atualizado para %d minutos.", minutes)))
+			// Se algum fluxo aguarda texto deste chat (ex: mensagem de ack), entrega a ele
+			if b.handlePendingInput(update.Message) {
 				continue
 			}
+
 			msg := tgbotapi.NewMessage(update.Message.Chat.ID, "Informe um comando.")
 			b.API.Send(msg)
 			continue
@@ -189,7 +257,7 @@ func (b *Bot) Start() {
 
 		cmd := update.Message.Command()
 		if handler, ok := b.Commands[cmd]; ok {
-			handler(update)
+			b.runCommand(handler, update)
 		}
 	}
 }
@@ -213,7 +281,7 @@ func (b *Bot) ExecuteCommand(cmd string, chatID int64) {
 
 	if handler, ok := b.Commands[commandName]; ok {
 		log.Printf("Executando handler via scheduler para comando: %s", commandName)
-		handler(fakeUpdate)
+		b.runCommand(handler, fakeUpdate)
 	} else {
 		log.Printf("Comando não encontrado: %s", commandName)
 		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Comando '%s' não encontrado", commandName))
@@ -276,17 +344,43 @@ func (b *Bot) handleStart(update tgbotapi.Update) {
 			"🎯 *Principais Funcionalidades:*\n\n"+
 			"🌐 *Monitoramento de Rede*\n"+
 			"• `/ping` - Testa conectividade\n"+
-			"• `/listip` - Lista hosts do Zabbix\n\n"+
+			"• `/listip` - Lista hosts do Zabbix\n"+
+			"• `/host` - Detalhes de um host do Zabbix\n\n"+
 			"📊 *Monitoramento Zabbix*\n"+
 			"• `/status_check` - Status dos hosts\n"+
+			"• `/status_monitor` - Monitorar hosts offline\n"+
+			"• `/monitor_add` - Criar monitor\n"+
+			"• `/monitor_list` - Listar monitores\n"+
+			"• `/monitor_stop` - Parar monitor\n"+
+			"• `/mute` - Silenciar avisos de um host\n"+
+			"• `/mutes` - Hosts silenciados\n"+
+			"• `/notify_window` - Horário de avisos do chat\n"+
+			"• `/uptime <host> [período]` - Disponibilidade de um host\n"+
+			"• `/availability [grupo] [mês]` - Relatório de disponibilidade\n"+
+			"• `/problems` - Problemas ativos\n"+
+			"• `/history` - Gráfico do histórico de um item\n"+
+			"• `/latest` - Últimos dados de um host\n"+
+			"• `/item` - Valor atual de um item\n"+
 			"• `/printers_counter` - Contadores de impressoras\n"+
-			"• `/protheus_status` - Status Protheus/TOTVS\n\n"+
+			"• `/protheus_status` - Status Protheus/TOTVS\n"+
+			"• `/zbx_use` - Escolher servidor Zabbix\n"+
+			"• `/zbx_refresh` - Atualizar inventário do Zabbix\n\n"+
 			"⚙️ *Gerenciamento de Serviços*\n"+
 			"• `/services` - Gerenciar serviços remotos\n"+
-			"• `/list_services` - Listar serviços\n\n"+
+			"• `/list_services` - Listar serviços\n"+
+			"• `/zbx_script` - Executar script do Zabbix (admin)\n\n"+
 			"💻 *Gerenciamento Windows*\n"+
 			"• `/restart_win` - Reiniciar host\n"+
 			"• `/shutdown_win` - Desligar host\n\n"+
+			"🛠️ *Manutenções Zabbix*\n"+
+			"• `/maintenance_add` - Criar manutenção\n"+
+			"• `/maintenance_list` - Listar manutenções\n"+
+			"• `/maintenance_remove` - Remover manutenção\n\n"+
+			"🗂️ *Cadastro Zabbix (admin)*\n"+
+			"• `/zbx_add_host` - Cadastrar host\n"+
+			"• `/zbx_enable` - Ativar monitoramento\n"+
+			"• `/zbx_disable` - Desativar monitoramento\n"+
+			"• `/import_hosts` - Importar hosts de planilha\n\n"+
 			"📧 *Relatórios*\n"+
 			"• `/send_mail_counter` - Enviar contadores por email\n\n"+
 			"📁 *Upload de Arquivos*\n"+
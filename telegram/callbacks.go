@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// callbackHandler trata um botão inline. args são as partes do callback data
+// separadas por ":" após o prefixo, ex: "event:ack:123" -> ["ack", "123"]
+type callbackHandler func(query *tgbotapi.CallbackQuery, args []string)
+
+// pendingInput é chamado com a próxima mensagem de texto do chat
+type pendingInput func(msg *tgbotapi.Message)
+
+func (b *Bot) initCallbacks() {
+	b.Callbacks = map[string]callbackHandler{
+		"monitor":    b.handleMonitorCallback,
+		"mute":       b.handleMuteCallback,
+		"incident":   b.handleIncidentCallback,
+		"problems":   b.handleProblemsCallback,
+		"event":      b.handleEventCallback,
+		"host":       b.handleHostCallback,
+		"addhost":    b.handleAddHostCallback,
+		"hostimport": b.handleHostImportCallback,
+		"script":     b.handleScriptCallback,
+		"server":     b.handleServerCallback,
+	}
+}
+
+// handleCallback encaminha o callback ao handler registrado para o prefixo do data
+func (b *Bot) handleCallback(query *tgbotapi.CallbackQuery) {
+	if query.Message == nil || !b.callbackAllowed(query.Message.Chat.ID, query.Data) {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Não autorizado."))
+		return
+	}
+
+	parts := strings.Split(query.Data, ":")
+	handler, ok := b.Callbacks[parts[0]]
+	if !ok || len(parts) < 2 {
+		log.Printf("Callback desconhecido: %s", query.Data)
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+		return
+	}
+
+	handler(query, parts[1:])
+}
+
+// callbackAllowed indica se o chat pode usar o botão. Os chats de escalonamento fora
+// de TELEGRAM_ALLOWED_CHAT_ID só podem reconhecer incidentes.
+func (b *Bot) callbackAllowed(chatID int64, data string) bool {
+	if b.AllowedChats[chatID] {
+		return true
+	}
+	return strings.HasPrefix(data, "incident:") && b.escalationChat(chatID)
+}
+
+// waitForInput registra um fluxo que receberá a próxima mensagem de texto do chat
+func (b *Bot) waitForInput(chatID int64, handler pendingInput) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[chatID] = handler
+}
+
+// handlePendingInput entrega a mensagem ao fluxo que a aguarda, se houver.
+// Retorna false quando nenhum fluxo está aguardando texto do chat.
+func (b *Bot) handlePendingInput(msg *tgbotapi.Message) bool {
+	b.mu.Lock()
+	handler, ok := b.pending[msg.Chat.ID]
+	if ok {
+		delete(b.pending, msg.Chat.ID)
+	}
+	b.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	handler(msg)
+	return true
+}
+
+// userDisplayName devolve o nome do usuário do Telegram, com @username quando existir
+func userDisplayName(user *tgbotapi.User) string {
+	if user == nil {
+		return "desconhecido"
+	}
+
+	name := user.FirstName
+	if user.LastName != "" {
+		name += " " + user.LastName
+	}
+	if user.UserName != "" {
+		name += " (@" + user.UserName + ")"
+	}
+	return name
+}
@@ -0,0 +1,18 @@
+//go:build demo
+
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"LapaTelegramBot/zabbix/zabbixtest"
+	"log"
+)
+
+// demoServers sobe o servidor fake do zabbixtest com um ambiente de exemplo, sem
+// precisar de um Zabbix real. Só existe nos binários compilados com -tags demo, para
+// que o servidor fake não vá para o binário de produção.
+func demoServers() (*zabbix.Servers, bool) {
+	demo := zabbixtest.NewDemoServer()
+	log.Printf("⚠️  Modo demonstração: usando o Zabbix fake em %s, com dados de exemplo", demo.URL)
+	return zabbix.NewServers(demo.DemoClient()), true
+}
@@ -0,0 +1,10 @@
+//go:build !demo
+
+package bot
+
+import "LapaTelegramBot/zabbix"
+
+// demoServers não está disponível sem -tags demo (ver demo.go)
+func demoServers() (*zabbix.Servers, bool) {
+	return nil, false
+}
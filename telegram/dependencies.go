@@ -0,0 +1,39 @@
+package bot
+
+import (
+	"LapaTelegramBot/config"
+	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/zabbix"
+	"context"
+	"fmt"
+	"log"
+)
+
+// dependenciesFile define de qual host cada host depende, ao lado de schedules.json
+const dependenciesFile = "dependencies.json"
+
+// initDependencies carrega as dependências entre hosts do arquivo
+func (b *Bot) initDependencies() {
+	deps, err := monitor.ReadDependencies(dependenciesFile)
+	if err != nil {
+		log.Printf("⚠️  Erro ao ler %s: %v", dependenciesFile, err)
+		deps = monitor.Dependencies{}
+	}
+	b.fileDependencies = deps
+}
+
+// dependencies junta as dependências da tag MONITOR_PARENT_TAG dos hosts do servidor,
+// lidas do inventário, com as do arquivo, que prevalecem. Se a consulta falhar,
+// devolve só as do arquivo e o erro.
+func (b *Bot) dependencies(ctx context.Context, z *zabbix.Client) (monitor.Dependencies, error) {
+	tag := config.Get("MONITOR_PARENT_TAG", "parent")
+	if tag == "" {
+		return b.fileDependencies, nil
+	}
+
+	tagged, err := monitor.DependenciesFromTags(ctx, z, tag)
+	if err != nil {
+		return b.fileDependencies, fmt.Errorf("tag %s dos hosts: %w", tag, err)
+	}
+	return tagged.Merge(b.fileDependencies), nil
+}
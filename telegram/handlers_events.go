@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// eventKeyboard monta os botões de ação exibidos nas mensagens de problema.
+// ref é o ID do evento, com o servidor de origem quando houver mais de um (ver serverRef).
+func eventKeyboard(ref string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Reconhecer", "event:ack:"+ref),
+			tgbotapi.NewInlineKeyboardButtonData("💬 Mensagem", "event:msg:"+ref),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⚠️ Severidade", "event:sev:"+ref),
+			tgbotapi.NewInlineKeyboardButtonData("🔒 Fechar problema", "event:close:"+ref),
+		),
+	)
+}
+
+// severityKeyboard lista as severidades para o botão "Severidade"
+func severityKeyboard(ref string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for s := zabbix.SeverityNotClassified; s <= zabbix.SeverityDisaster; s++ {
+		label := severityIcon(s) + " " + zabbix.SeverityName(s)
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("event:setsev:%s:%d", ref, s)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("↩️ Voltar", "event:back:"+ref)))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleEventCallback trata os botões de ação sobre eventos do Zabbix.
+// Formato: event:<show|ack|msg|sev|setsev|close|closeok|back>:<eventID>[@servidor][:<severidade>]
+func (b *Bot) handleEventCallback(query *tgbotapi.CallbackQuery, args []string) {
+	if len(args) < 2 {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+		return
+	}
+
+	action, ref := args[0], args[1]
+	chatID := query.Message.Chat.ID
+	z, eventID := b.resolveRef(chatID, ref)
+	messageID := query.Message.MessageID
+	user := userDisplayName(query.From)
+
+	switch action {
+	case "show":
+		b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+		b.sendProblemMessage(chatID, ref)
+
+	case "ack":
+		b.acknowledge(query, ref, zabbix.Acknowledgement{
+			Action:  zabbix.ActionAcknowledge | zabbix.ActionMessage,
+			Message: "Reconhecido via Telegram por " + user,
+		}, "Problema reconhecido.")
+
+	case "msg":
+		b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+		b.waitForInput(chatID, func(msg *tgbotapi.Message) {
+			text := strings.TrimSpace(msg.Text)
+			if text == "" {
+				b.API.Send(tgbotapi.NewMessage(chatID, "Mensagem vazia, nada foi enviado ao Zabbix."))
+				return
+			}
+
+			ctx, cancel := b.zabbixContext()
+			defer cancel()
+
+			err := z.AcknowledgeEvent(ctx, []string{eventID}, zabbix.Acknowledgement{
+				Action:  zabbix.ActionMessage,
+				Message: fmt.Sprintf("%s — %s via Telegram", text, userDisplayName(msg.From)),
+			})
+			if err != nil {
+				b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao enviar mensagem ao Zabbix:\n%v", err)))
+				log.Println(err)
+				return
+			}
+			b.API.Send(tgbotapi.NewMessage(chatID, "✅ Mensagem adicionada ao problema."))
+			b.refreshProblemMessage(chatID, messageID, ref)
+		})
+		b.API.Send(tgbotapi.NewMessage(chatID, "Envie a mensagem que será adicionada ao problema."))
+
+	case "sev":
+		b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+		b.API.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, severityKeyboard(ref)))
+
+	case "setsev":
+		severity, err := strconv.Atoi(args[len(args)-1])
+		if len(args) < 3 || err != nil {
+			b.API.Request(tgbotapi.NewCallback(query.ID, "Severidade inválida."))
+			return
+		}
+		b.acknowledge(query, ref, zabbix.Acknowledgement{
+			Action:   zabbix.ActionChangeSeverity | zabbix.ActionMessage,
+			Severity: severity,
+			Message:  fmt.Sprintf("Severidade alterada para %s via Telegram por %s", zabbix.SeverityName(severity), user),
+		}, "Severidade alterada.")
+
+	case "close":
+		// Pede confirmação antes de fechar o problema
+		b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+		confirm := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("🔒 Confirmar fechamento", "event:closeok:"+ref),
+				tgbotapi.NewInlineKeyboardButtonData("↩️ Cancelar", "event:back:"+ref),
+			),
+		)
+		b.API.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, confirm))
+
+	case "closeok":
+		b.acknowledge(query, ref, zabbix.Acknowledgement{
+			Action:  zabbix.ActionClose | zabbix.ActionMessage,
+			Message: "Fechado via Telegram por " + user,
+		}, "Fechamento solicitado.")
+
+	case "back":
+		b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+		b.API.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, eventKeyboard(ref)))
+
+	default:
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+	}
+}
+
+// acknowledge envia a ação ao Zabbix, responde o callback e atualiza a mensagem do problema
+func (b *Bot) acknowledge(query *tgbotapi.CallbackQuery, ref string, ack zabbix.Acknowledgement, done string) {
+	z, eventID := b.resolveRef(query.Message.Chat.ID, ref)
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	if err := z.AcknowledgeEvent(ctx, []string{eventID}, ack); err != nil {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Erro ao atualizar o evento."))
+		b.API.Send(tgbotapi.NewMessage(query.Message.Chat.ID, fmt.Sprintf("❌ Erro ao atualizar evento %s:\n%v", eventID, err)))
+		log.Println(err)
+		return
+	}
+
+	b.API.Request(tgbotapi.NewCallback(query.ID, done))
+	b.refreshProblemMessage(query.Message.Chat.ID, query.Message.MessageID, ref)
+}
+
+// sendProblemMessage envia uma mensagem com os detalhes do problema e os botões de ação
+func (b *Bot) sendProblemMessage(chatID int64, ref string) {
+	z, eventID := b.resolveRef(chatID, ref)
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	problem, ok, err := z.GetProblem(ctx, eventID)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+	if !ok {
+		b.API.Send(tgbotapi.NewMessage(chatID, "✅ Este problema não está mais ativo."))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, b.renderProblem(problem))
+	msg.ReplyMarkup = eventKeyboard(ref)
+	b.API.Send(msg)
+}
+
+// refreshProblemMessage reescreve a mensagem do problema com o estado atual no Zabbix
+func (b *Bot) refreshProblemMessage(chatID int64, messageID int, ref string) {
+	z, eventID := b.resolveRef(chatID, ref)
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	problem, ok, err := z.GetProblem(ctx, eventID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !ok {
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, "✅ Problema resolvido ou fechado.")
+		b.API.Send(edit)
+		return
+	}
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, b.renderProblem(problem), eventKeyboard(ref))
+	b.API.Send(edit)
+}
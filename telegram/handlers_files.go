@@ -1,6 +1,8 @@
 package bot
 
 import (
+	"LapaTelegramBot/file_handler"
+	"LapaTelegramBot/zabbix"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,6 +37,23 @@ func (b *Bot) handleFileUpload(update tgbotapi.Update) {
 		return
 	}
 
+	// Planilha de importação de hosts: exige chat administrador e formato suportado
+	importHosts := isHostImport(update.Message)
+	var importTarget *zabbix.Client
+	if importHosts {
+		if !b.requireAdmin(update.Message.Chat.ID) {
+			return
+		}
+		if !file_handler.IsHostImportFile(fileName) {
+			b.API.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "❌ Envie a planilha de importação em formato .xlsx ou .csv."))
+			return
+		}
+		var ok bool
+		if importTarget, ok = b.hostImportTarget(update.Message); !ok {
+			return
+		}
+	}
+
 	// Feedback para o usuário
 	processingMsg := tgbotapi.NewMessage(update.Message.Chat.ID, fmt.Sprintf("⏳ Recebendo arquivo: %s...", fileName))
 	tempMsg, _ := b.API.Send(processingMsg)
@@ -80,6 +99,13 @@ func (b *Bot) handleFileUpload(update tgbotapi.Update) {
 		return
 	}
 
+	if importHosts {
+		out.Close()
+		b.editMessage(update.Message.Chat.ID, tempMsg.MessageID, "⏳ Validando hosts da planilha...")
+		b.importHostsFromFile(importTarget, update.Message.Chat.ID, destPath, fileName)
+		return
+	}
+
 	b.editMessage(update.Message.Chat.ID, tempMsg.MessageID, fmt.Sprintf("✅ Arquivo *%s* salvo com sucesso em `%s`!", fileName, uploadDir))
 }
 
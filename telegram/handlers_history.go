@@ -0,0 +1,209 @@
+package bot
+
+import (
+	"LapaTelegramBot/chart"
+	"LapaTelegramBot/config"
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// historyMaxSeries limita quantos itens entram no mesmo gráfico
+const historyMaxSeries = 5
+
+func (b *Bot) handleHistory(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /history SRV01 icmppingsec 2d
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 3 {
+		msg := tgbotapi.NewMessage(chatID, "Uso: /history <host> <key do item> [período] [diario]\n"+
+			"Exemplos:\n/history SRV01 icmppingsec 2d\n/history SRV01 vfs.fs.size[*,pused] 30d\n/history IMP01 contador.total 30d diario")
+		b.API.Send(msg)
+		return
+	}
+
+	hostName, keySearch := parts[1], parts[2]
+	period := 24 * time.Hour
+	daily := false
+	for _, arg := range parts[3:] {
+		if strings.EqualFold(arg, "diario") {
+			daily = true
+			continue
+		}
+		d, err := parseDuration(arg)
+		if err != nil {
+			b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()+"\nExemplos: 6h, 2d, 30d"))
+			return
+		}
+		period = d
+	}
+
+	processingMsg := tgbotapi.NewMessage(chatID, fmt.Sprintf("⏳ Consultando histórico de %s em %s...", keySearch, hostName))
+	tempMsg, _ := b.API.Send(processingMsg)
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	host, ok, err := z.GetHostByName(ctx, hostName)
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+	if !ok {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Host %s não encontrado.", hostName)))
+		return
+	}
+
+	items, err := z.GetItems(ctx, []string{host.Hostid}, keySearch)
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	var numeric []zabbix.Item
+	for _, item := range items {
+		if item.Numeric() {
+			numeric = append(numeric, item)
+		}
+	}
+	if len(numeric) == 0 {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Nenhum item numérico com key %s em %s.", keySearch, host.Host)))
+		return
+	}
+	if len(numeric) > historyMaxSeries {
+		numeric = numeric[:historyMaxSeries]
+	}
+
+	// Períodos maiores que a retenção do histórico usam as médias horárias (trends)
+	till := time.Now()
+	from := till.Add(-period)
+	useTrends := period > config.GetDuration("ZABBIX_HISTORY_MAX_AGE", 7*24*time.Hour)
+
+	var series []chart.Series
+	var caption strings.Builder
+	caption.WriteString(fmt.Sprintf("📈 %s — últimos %s", host.Host, formatDuration(period)))
+	if useTrends {
+		caption.WriteString(" (médias horárias)")
+	}
+	if daily {
+		caption.WriteString(" — diferença diária")
+	}
+	caption.WriteString("\n")
+
+	for _, item := range numeric {
+		var points []zabbix.HistoryPoint
+		if useTrends {
+			points, err = z.GetTrends(ctx, item, from, till)
+		} else {
+			points, err = z.GetHistory(ctx, item, from, till)
+			// Histórico vazio pode indicar retenção curta; tenta as trends
+			if err == nil && len(points) == 0 {
+				points, err = z.GetTrends(ctx, item, from, till)
+			}
+		}
+		if err != nil {
+			b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao consultar histórico de %s:\n%v", item.Key, err)))
+			log.Println(err)
+			return
+		}
+
+		if daily {
+			points = dailyDelta(points)
+		}
+		if len(points) == 0 {
+			continue
+		}
+
+		s := chart.Series{Name: item.Name}
+		for _, p := range points {
+			s.Points = append(s.Points, chart.Point{Time: p.Clock, Value: p.Value})
+		}
+		series = append(series, s)
+
+		minV, avgV, maxV := summarize(points)
+		caption.WriteString(fmt.Sprintf("\n• %s\n  último %s | mín %s | méd %s | máx %s",
+			item.Name,
+			formatItemValue(points[len(points)-1].Value, item.Units),
+			formatItemValue(minV, item.Units),
+			formatItemValue(avgV, item.Units),
+			formatItemValue(maxV, item.Units),
+		))
+	}
+
+	if len(series) == 0 {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, "ℹ️ Nenhum valor coletado no período."))
+		return
+	}
+
+	img, err := chart.Line(series, chart.Options{
+		Title: fmt.Sprintf("%s - %s", host.Host, keySearch),
+		Unit:  numeric[0].Units,
+	})
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao gerar gráfico:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: "history.png", Bytes: img})
+	photo.Caption = truncateCaption(caption.String())
+	if _, err := b.API.Send(photo); err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao enviar gráfico:\n%v", err)))
+		log.Printf("Erro ao enviar gráfico do /history: %v", err)
+		return
+	}
+	b.API.Request(tgbotapi.NewDeleteMessage(chatID, tempMsg.MessageID))
+}
+
+// dailyDelta transforma um contador acumulado (ex: contador.total) no quanto ele
+// cresceu a cada dia, comparando o último valor de cada dia com o do dia anterior
+func dailyDelta(points []zabbix.HistoryPoint) []zabbix.HistoryPoint {
+	var days []zabbix.HistoryPoint
+	for _, p := range points {
+		y, m, d := p.Clock.Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, p.Clock.Location())
+		if n := len(days); n > 0 && days[n-1].Clock.Equal(day) {
+			days[n-1].Value = p.Value
+			continue
+		}
+		days = append(days, zabbix.HistoryPoint{Clock: day, Value: p.Value})
+	}
+
+	var deltas []zabbix.HistoryPoint
+	for i := 1; i < len(days); i++ {
+		diff := days[i].Value - days[i-1].Value
+		// Contador zerado (troca de placa, reset) não deve gerar valor negativo
+		if diff < 0 {
+			diff = 0
+		}
+		deltas = append(deltas, zabbix.HistoryPoint{Clock: days[i].Clock, Value: diff})
+	}
+	return deltas
+}
+
+func summarize(points []zabbix.HistoryPoint) (minV, avgV, maxV float64) {
+	minV, maxV = points[0].Value, points[0].Value
+	sum := 0.0
+	for _, p := range points {
+		minV = min(minV, p.Value)
+		maxV = max(maxV, p.Value)
+		sum += p.Value
+	}
+	return minV, sum / float64(len(points)), maxV
+}
+
+// formatItemValue formata um valor numérico com a unidade do item
+func formatItemValue(v float64, units string) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d%s", int64(v), units)
+	}
+	return fmt.Sprintf("%.2f%s", v, units)
+}
@@ -0,0 +1,203 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// hostMaxMatches limita os botões oferecidos quando a busca é ambígua
+const hostMaxMatches = 10
+
+var interfaceTypes = map[string]string{"1": "Agente", "2": "SNMP", "3": "IPMI", "4": "JMX"}
+
+var interfaceAvailability = map[string]string{"0": "❔ Desconhecido", "1": "✅ Disponível", "2": "❌ Indisponível"}
+
+func (b *Bot) handleHost(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /host SRV01 ou /host 192.168.0.10
+	query := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/"+update.Message.Command()))
+	if query == "" {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /host <nome ou IP>\nExemplo: /host SRV01"))
+		return
+	}
+
+	processingMsg := tgbotapi.NewMessage(chatID, fmt.Sprintf("⏳ Procurando %s no Zabbix...", query))
+	tempMsg, _ := b.API.Send(processingMsg)
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	hosts, err := z.SearchHosts(ctx, query)
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	switch len(hosts) {
+	case 0:
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Nenhum host encontrado para %s.", query)+inventoryNote(z)))
+	case 1:
+		b.API.Request(tgbotapi.NewDeleteMessage(chatID, tempMsg.MessageID))
+		b.sendHostDetails(z, chatID, hosts[0].Hostid)
+	default:
+		// Busca ambígua: oferece um botão para cada host encontrado
+		var rows [][]tgbotapi.InlineKeyboardButton
+		for i, h := range hosts {
+			if i == hostMaxMatches {
+				break
+			}
+			label := h.Host
+			if h.Name != "" && h.Name != h.Host {
+				label += " (" + h.Name + ")"
+			}
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(label, "host:show:"+b.serverRef(z.Name, h.Hostid))))
+		}
+
+		text := fmt.Sprintf("🔍 %d hosts encontrados para %s. Escolha um:", len(hosts), query)
+		if len(hosts) > hostMaxMatches {
+			text += fmt.Sprintf("\n(mostrando os %d mais próximos)", hostMaxMatches)
+		}
+		text += inventoryNote(z)
+		edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, tempMsg.MessageID, text, tgbotapi.NewInlineKeyboardMarkup(rows...))
+		b.API.Send(edit)
+	}
+}
+
+// handleHostCallback trata os botões de escolha do /host. Formato: host:show:<hostid>[@servidor]
+func (b *Bot) handleHostCallback(query *tgbotapi.CallbackQuery, args []string) {
+	if len(args) < 2 || args[0] != "show" {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+		return
+	}
+
+	z, hostID := b.resolveRef(query.Message.Chat.ID, args[1])
+	b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+	b.sendHostDetails(z, query.Message.Chat.ID, hostID)
+}
+
+// sendHostDetails envia o cadastro completo do host com o estado atual do icmpping
+func (b *Bot) sendHostDetails(z *zabbix.Client, chatID int64, hostID string) {
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	host, ok, err := z.GetHostDetails(ctx, hostID)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+	if !ok {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ Host não encontrado."))
+		return
+	}
+
+	items, err := z.GetItems(ctx, []string{hostID}, "icmpping")
+	if err != nil {
+		log.Println(err)
+	}
+
+	text := renderHostDetails(host, items)
+	if b.Servers.Multiple() {
+		text = fmt.Sprintf("🏢 Servidor: %s\n", z.Name) + text
+	}
+	b.API.Send(tgbotapi.NewMessage(chatID, text))
+}
+
+func renderHostDetails(host zabbix.HostDetails, pingItems []zabbix.Item) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🖥️ %s\n", host.Host))
+	if host.Name != "" && host.Name != host.Host {
+		sb.WriteString(fmt.Sprintf("Nome visível: %s\n", host.Name))
+	}
+
+	status := "🟢 Monitorado"
+	if host.Status == "1" {
+		status = "⚪ Desativado"
+	}
+	if host.MaintenanceStatus == "1" {
+		status += " • 🛠️ Em manutenção"
+	}
+	sb.WriteString(status + "\n")
+
+	// Estado do icmpping (ignora icmppingloss/icmppingsec)
+	for _, item := range pingItems {
+		if item.Key != "icmpping" && !strings.HasPrefix(item.Key, "icmpping[") {
+			continue
+		}
+		ping := "❌ Sem resposta"
+		if item.Lastvalue == "1" {
+			ping = "✅ Respondendo"
+		}
+		if checked := item.LastCheck(); !checked.IsZero() {
+			ping += " (" + checked.Format("02/01 15:04") + ")"
+		}
+		sb.WriteString(fmt.Sprintf("Ping: %s\n", ping))
+		break
+	}
+
+	if len(host.Groups) > 0 {
+		names := make([]string, len(host.Groups))
+		for i, g := range host.Groups {
+			names[i] = g.Name
+		}
+		sb.WriteString(fmt.Sprintf("\n📂 Grupos: %s\n", strings.Join(names, ", ")))
+	}
+
+	if len(host.Templates) > 0 {
+		names := make([]string, len(host.Templates))
+		for i, t := range host.Templates {
+			names[i] = t.Name
+		}
+		sb.WriteString(fmt.Sprintf("📋 Templates: %s\n", strings.Join(names, ", ")))
+	}
+
+	if len(host.Interfaces) > 0 {
+		sb.WriteString("\n🌐 Interfaces:\n")
+		for _, i := range host.Interfaces {
+			kind := interfaceTypes[i.Type]
+			if kind == "" {
+				kind = i.Type
+			}
+			availability := interfaceAvailability[i.Available]
+			if availability == "" {
+				availability = interfaceAvailability["0"]
+			}
+			sb.WriteString(fmt.Sprintf("• %s %s:%s — %s\n", kind, i.Address(), i.Port, availability))
+		}
+	}
+
+	if len(host.Tags) > 0 {
+		sb.WriteString("\n🏷️ Tags:\n")
+		for _, t := range host.Tags {
+			if t.Value != "" {
+				sb.WriteString(fmt.Sprintf("• %s: %s\n", t.Tag, t.Value))
+			} else {
+				sb.WriteString(fmt.Sprintf("• %s\n", t.Tag))
+			}
+		}
+	}
+
+	if len(host.Inventory) > 0 {
+		fields := make([]string, 0, len(host.Inventory))
+		for f := range host.Inventory {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+
+		sb.WriteString("\n📦 Inventário:\n")
+		for _, f := range fields {
+			sb.WriteString(fmt.Sprintf("• %s: %s\n", f, host.Inventory[f]))
+		}
+	}
+
+	return sb.String()
+}
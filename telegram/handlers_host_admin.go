@@ -0,0 +1,283 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Etapas do fluxo guiado do /zbx_add_host
+const (
+	addHostStepName = iota
+	addHostStepIP
+	addHostStepType
+	addHostStepGroup
+	addHostStepTemplate
+	addHostStepConfirm
+)
+
+// addHostFlow guarda as respostas do /zbx_add_host de um chat
+type addHostFlow struct {
+	client   *zabbix.Client
+	step     int
+	host     zabbix.NewHost
+	group    string
+	template string
+}
+
+// requireAdmin avisa e retorna false quando o chat não pode usar comandos administrativos
+func (b *Bot) requireAdmin(chatID int64) bool {
+	if b.AdminChats[chatID] {
+		return true
+	}
+	b.API.Send(tgbotapi.NewMessage(chatID, "🚫 Comando restrito aos administradores."))
+	return false
+}
+
+func (b *Bot) handleZabbixEnableHost(update tgbotapi.Update, target zabbixTarget) {
+	b.setHostStatus(update, target.client, true)
+}
+
+func (b *Bot) handleZabbixDisableHost(update tgbotapi.Update, target zabbixTarget) {
+	b.setHostStatus(update, target.client, false)
+}
+
+func (b *Bot) setHostStatus(update tgbotapi.Update, z *zabbix.Client, enabled bool) {
+	chatID := update.Message.Chat.ID
+	if !b.requireAdmin(chatID) {
+		return
+	}
+
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) != 2 {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Uso: /%s <host>", update.Message.Command())))
+		return
+	}
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	host, ok, err := z.GetHostByName(ctx, parts[1])
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+	if !ok {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Host %s não encontrado.", parts[1])))
+		return
+	}
+
+	if err := z.SetHostStatus(ctx, host.Hostid, enabled); err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao atualizar host:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	state := "desativado ⚪"
+	if enabled {
+		state = "ativado 🟢"
+	}
+	log.Printf("Host %s %s por %s", host.Host, state, userDisplayName(update.Message.From))
+	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Monitoramento de %s %s.", host.Host, state)))
+}
+
+func (b *Bot) handleZabbixAddHost(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+	if !b.requireAdmin(chatID) {
+		return
+	}
+
+	z := target.client
+
+	b.mu.Lock()
+	b.addHostFlows[chatID] = &addHostFlow{client: z}
+	b.mu.Unlock()
+
+	b.API.Send(tgbotapi.NewMessage(chatID, "🆕 Cadastro de host no Zabbix"+b.serverLabel(z)+"\n(envie \"cancelar\" a qualquer momento)\n\n1️⃣ Informe o nome do host:"))
+	b.waitForInput(chatID, b.addHostStep)
+}
+
+// addHostStep recebe a resposta da etapa atual do /zbx_add_host e pede a próxima
+func (b *Bot) addHostStep(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	text := strings.TrimSpace(msg.Text)
+
+	b.mu.Lock()
+	flow, ok := b.addHostFlows[chatID]
+	b.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if strings.EqualFold(text, "cancelar") {
+		b.finishAddHost(chatID)
+		b.API.Send(tgbotapi.NewMessage(chatID, "Cadastro cancelado."))
+		return
+	}
+
+	// Em caso de resposta inválida, repete a mesma etapa
+	retry := func(reason string) {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+reason+"\nTente novamente:"))
+		b.waitForInput(chatID, b.addHostStep)
+	}
+	next := func(prompt string) {
+		flow.step++
+		b.API.Send(tgbotapi.NewMessage(chatID, prompt))
+		b.waitForInput(chatID, b.addHostStep)
+	}
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	switch flow.step {
+	case addHostStepName:
+		if text == "" || strings.ContainsAny(text, " \t") {
+			retry("O nome do host não pode conter espaços.")
+			return
+		}
+		if _, exists, err := flow.client.GetHostByName(ctx, text); err != nil {
+			retry(fmt.Sprintf("Erro ao consultar Zabbix: %v", err))
+			return
+		} else if exists {
+			retry(fmt.Sprintf("Já existe um host com o nome %s.", text))
+			return
+		}
+		flow.host.Host = text
+		next("2️⃣ Informe o IP do host:")
+
+	case addHostStepIP:
+		if net.ParseIP(text) == nil {
+			retry("IP inválido.")
+			return
+		}
+		if used, err := flow.client.HostsByIP(ctx, text); err != nil {
+			retry(fmt.Sprintf("Erro ao consultar Zabbix: %v", err))
+			return
+		} else if len(used) > 0 {
+			retry(fmt.Sprintf("O IP %s já está em uso por %s.", text, strings.Join(used, ", ")))
+			return
+		}
+		flow.host.IP = text
+		next("3️⃣ Tipo de interface: agente ou snmp?")
+
+	case addHostStepType:
+		switch strings.ToLower(text) {
+		case "agente", "agent":
+			flow.host.InterfaceType = zabbix.InterfaceAgent
+		case "snmp":
+			flow.host.InterfaceType = zabbix.InterfaceSNMP
+		default:
+			retry("Responda agente ou snmp.")
+			return
+		}
+		flow.host.Port = zabbix.DefaultPort(flow.host.InterfaceType)
+		next("4️⃣ Informe o nome do grupo:")
+
+	case addHostStepGroup:
+		groupID, err := flow.client.GroupID(ctx, text)
+		if err != nil {
+			retry(err.Error())
+			return
+		}
+		flow.group = text
+		flow.host.GroupIDs = []string{groupID}
+		next("5️⃣ Informe o nome do template (ou \"-\" para nenhum):")
+
+	case addHostStepTemplate:
+		if text != "-" {
+			templateID, ok, err := flow.client.TemplateID(ctx, text)
+			if err != nil {
+				retry(fmt.Sprintf("Erro ao consultar Zabbix: %v", err))
+				return
+			}
+			if !ok {
+				retry(fmt.Sprintf("Template %s não encontrado.", text))
+				return
+			}
+			flow.template = text
+			flow.host.TemplateIDs = []string{templateID}
+		}
+		flow.step = addHostStepConfirm
+
+		kind := "Agente"
+		if flow.host.InterfaceType == zabbix.InterfaceSNMP {
+			kind = "SNMP"
+		}
+		template := flow.template
+		if template == "" {
+			template = "(nenhum)"
+		}
+
+		summary := fmt.Sprintf("📋 Confirme o cadastro%s:\n\nHost: %s\nInterface: %s %s:%s\nGrupo: %s\nTemplate: %s",
+			b.serverLabel(flow.client), flow.host.Host, kind, flow.host.IP, flow.host.Port, flow.group, template)
+		confirm := tgbotapi.NewMessage(chatID, summary)
+		confirm.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("✅ Criar host", "addhost:confirm"),
+				tgbotapi.NewInlineKeyboardButtonData("❌ Cancelar", "addhost:cancel"),
+			),
+		)
+		b.API.Send(confirm)
+	}
+}
+
+// handleAddHostCallback trata a confirmação do /zbx_add_host. Formato: addhost:<confirm|cancel>
+func (b *Bot) handleAddHostCallback(query *tgbotapi.CallbackQuery, args []string) {
+	chatID := query.Message.Chat.ID
+
+	b.mu.Lock()
+	flow, ok := b.addHostFlows[chatID]
+	b.mu.Unlock()
+
+	// Remove os botões para evitar confirmação dupla
+	b.API.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, query.Message.MessageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+
+	if !ok || flow.step != addHostStepConfirm {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Cadastro expirado."))
+		return
+	}
+	b.finishAddHost(chatID)
+
+	if args[0] != "confirm" {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Cadastro cancelado."))
+		b.API.Send(tgbotapi.NewMessage(chatID, "Cadastro cancelado."))
+		return
+	}
+	if !b.AdminChats[chatID] {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Comando restrito aos administradores."))
+		return
+	}
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	// Valida de novo: outro usuário pode ter cadastrado o mesmo nome/IP nesse meio tempo
+	if err := flow.client.ValidateNewHost(ctx, &flow.host, flow.group, flow.template); err != nil {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Cadastro inválido."))
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ %v", err)))
+		return
+	}
+
+	hostID, err := flow.client.CreateHost(ctx, flow.host)
+	if err != nil {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Erro ao criar host."))
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao criar host:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	log.Printf("Host %s (ID %s) criado por %s", flow.host.Host, hostID, userDisplayName(query.From))
+	b.API.Request(tgbotapi.NewCallback(query.ID, "Host criado."))
+	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("✅ Host %s criado no Zabbix (ID %s).", flow.host.Host, hostID)))
+}
+
+func (b *Bot) finishAddHost(chatID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.addHostFlows, chatID)
+}
@@ -0,0 +1,208 @@
+package bot
+
+import (
+	"LapaTelegramBot/file_handler"
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// hostImport guarda a importação validada de um chat, aguardando confirmação
+type hostImport struct {
+	client   *zabbix.Client
+	fileName string
+	plans    []zabbix.HostImportPlan
+}
+
+// isHostImport indica se o arquivo foi enviado com a legenda /import_hosts
+func isHostImport(msg *tgbotapi.Message) bool {
+	fields := strings.Fields(msg.Caption)
+	if len(fields) == 0 {
+		return false
+	}
+	command, _, _ := strings.Cut(fields[0], "@")
+	return command == "/import_hosts"
+}
+
+// hostImportTarget devolve o servidor da importação: --server na legenda ou o padrão do chat
+func (b *Bot) hostImportTarget(msg *tgbotapi.Message) (*zabbix.Client, bool) {
+	server, _, found := extractServerOption(msg.Caption)
+	if !found {
+		return b.zabbixForChat(msg.Chat.ID), true
+	}
+
+	z, ok := b.Servers.Get(server)
+	if !ok {
+		b.API.Send(tgbotapi.NewMessage(msg.Chat.ID, fmt.Sprintf("❌ Servidor %s não configurado.\nDisponíveis: %s", server, strings.Join(b.Servers.Names(), ", "))))
+	}
+	return z, ok
+}
+
+// handleImportHosts responde ao comando sem arquivo com as instruções de uso
+func (b *Bot) handleImportHosts(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+	if !b.requireAdmin(chatID) {
+		return
+	}
+
+	text := "📥 *Importação de hosts*\n\n" +
+		"Envie uma planilha `.xlsx` ou `.csv` com a legenda `/import_hosts`.\n\n" +
+		"A primeira linha deve conter o cabeçalho:\n" +
+		"`host | ip | tipo | grupo | template`\n\n" +
+		"• *tipo*: `agente` (padrão) ou `snmp`\n" +
+		"• *template*: opcional\n\n" +
+		"Antes de alterar o Zabbix, o bot mostra o que será criado ou atualizado e pede confirmação."
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	b.API.Send(msg)
+}
+
+// importHostsFromFile valida a planilha recebida e mostra a prévia da importação
+func (b *Bot) importHostsFromFile(z *zabbix.Client, chatID int64, path, fileName string) {
+	rows, err := file_handler.ReadHostImport(path)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao ler planilha:\n%v", err)))
+		return
+	}
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	plans, err := z.PlanHostImport(ctx, rows)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	pending := 0
+	for _, p := range plans {
+		if p.Action == zabbix.ImportCreate || p.Action == zabbix.ImportUpdate {
+			pending++
+		}
+	}
+
+	parts := splitMessage(renderImportPlan(fileName+b.serverLabel(z), plans))
+	for i, part := range parts {
+		msg := tgbotapi.NewMessage(chatID, part)
+		// Os botões vão na última parte da prévia
+		if i == len(parts)-1 && pending > 0 {
+			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+				tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("✅ Aplicar %d alterações", pending), "hostimport:confirm"),
+					tgbotapi.NewInlineKeyboardButtonData("❌ Cancelar", "hostimport:cancel"),
+				),
+			)
+		}
+		b.API.Send(msg)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if pending > 0 {
+		b.hostImports[chatID] = &hostImport{client: z, fileName: fileName, plans: plans}
+	} else {
+		delete(b.hostImports, chatID)
+	}
+}
+
+// renderImportPlan monta a prévia da importação, linha a linha
+func renderImportPlan(fileName string, plans []zabbix.HostImportPlan) string {
+	counts := make(map[zabbix.ImportAction]int)
+	var sb strings.Builder
+
+	for _, p := range plans {
+		counts[p.Action]++
+		r := p.Row
+
+		switch p.Action {
+		case zabbix.ImportCreate:
+			sb.WriteString(fmt.Sprintf("➕ L%d %s (%s) → %s", r.Line, r.Host, r.IP, r.Group))
+			if r.Template != "" {
+				sb.WriteString(" / " + r.Template)
+			}
+		case zabbix.ImportUpdate:
+			sb.WriteString(fmt.Sprintf("✏️ L%d %s: %s", r.Line, r.Host, strings.Join(p.Changes, ", ")))
+		case zabbix.ImportUnchanged:
+			sb.WriteString(fmt.Sprintf("⏸️ L%d %s: sem alterações", r.Line, r.Host))
+		default:
+			sb.WriteString(fmt.Sprintf("❌ L%d %s: %v", r.Line, r.Host, p.Err))
+		}
+		sb.WriteString("\n")
+	}
+
+	header := fmt.Sprintf("📥 Prévia da importação de %s\n\n➕ Criar: %d\n✏️ Atualizar: %d\n⏸️ Sem alterações: %d\n❌ Inválidos: %d\n\n",
+		fileName, counts[zabbix.ImportCreate], counts[zabbix.ImportUpdate], counts[zabbix.ImportUnchanged], counts[zabbix.ImportInvalid])
+
+	footer := ""
+	if counts[zabbix.ImportCreate]+counts[zabbix.ImportUpdate] == 0 {
+		footer = "\nNada a importar."
+	} else if counts[zabbix.ImportInvalid] > 0 {
+		footer = "\nLinhas inválidas serão ignoradas."
+	}
+	return header + sb.String() + footer
+}
+
+// handleHostImportCallback aplica ou descarta a importação pendente. Formato: hostimport:<confirm|cancel>
+func (b *Bot) handleHostImportCallback(query *tgbotapi.CallbackQuery, args []string) {
+	chatID := query.Message.Chat.ID
+
+	b.mu.Lock()
+	imp, ok := b.hostImports[chatID]
+	delete(b.hostImports, chatID)
+	b.mu.Unlock()
+
+	// Remove os botões para evitar aplicar a mesma importação duas vezes
+	b.API.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, query.Message.MessageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+
+	if !ok {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Importação expirada. Envie a planilha novamente."))
+		return
+	}
+	if args[0] != "confirm" {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Importação cancelada."))
+		b.API.Send(tgbotapi.NewMessage(chatID, "Importação cancelada."))
+		return
+	}
+	if !b.AdminChats[chatID] {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Comando restrito aos administradores."))
+		return
+	}
+
+	b.API.Request(tgbotapi.NewCallback(query.ID, "Aplicando importação..."))
+	log.Printf("Importação de hosts (%s) confirmada por %s", imp.fileName, userDisplayName(query.From))
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	results := imp.client.ApplyHostImport(ctx, imp.plans)
+
+	var sb strings.Builder
+	succeeded, failed := 0, 0
+	for i, p := range imp.plans {
+		if p.Action != zabbix.ImportCreate && p.Action != zabbix.ImportUpdate {
+			continue
+		}
+
+		verb := "criado"
+		if p.Action == zabbix.ImportUpdate {
+			verb = "atualizado"
+		}
+		if err := results[i]; err != nil {
+			failed++
+			sb.WriteString(fmt.Sprintf("❌ L%d %s: %v\n", p.Row.Line, p.Row.Host, err))
+			log.Println(err)
+		} else {
+			succeeded++
+			sb.WriteString(fmt.Sprintf("✅ L%d %s %s\n", p.Row.Line, p.Row.Host, verb))
+		}
+	}
+
+	report := fmt.Sprintf("📥 Resultado da importação de %s\n\n✅ Sucesso: %d\n❌ Falhas: %d\n\n%s", imp.fileName, succeeded, failed, sb.String())
+	for _, part := range splitMessage(report) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+}
@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// startInventory inicia a atualização do inventário de cada servidor em segundo plano
+func (b *Bot) startInventory() {
+	for _, z := range b.Servers.All() {
+		if !z.Inventory().Enabled() {
+			log.Printf("Inventário do Zabbix %s desativado, consultas vão direto à API", z.Name)
+			continue
+		}
+		go z.RunInventory(context.Background())
+	}
+}
+
+func (b *Bot) handleZabbixRefresh(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	processingMsg := tgbotapi.NewMessage(chatID, "⏳ Atualizando inventário do Zabbix...")
+	tempMsg, _ := b.API.Send(processingMsg)
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	var lines []string
+	for _, z := range b.zabbixTargets(target) {
+		lines = append(lines, refreshInventory(ctx, z)+b.serverLabel(z))
+	}
+
+	b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, "🗃️ Inventário do Zabbix\n\n"+strings.Join(lines, "\n")))
+}
+
+// refreshInventory atualiza o inventário do servidor e descreve o resultado
+func refreshInventory(ctx context.Context, z *zabbix.Client) string {
+	inv := z.Inventory()
+	if !inv.Enabled() {
+		return "⏸️ Cache desativado (ZABBIX_INVENTORY_INTERVAL=0)"
+	}
+
+	previous := inv.UpdatedAt()
+	if err := z.RefreshInventory(ctx); err != nil {
+		log.Println(err)
+		text := fmt.Sprintf("❌ Erro ao atualizar: %v", err)
+		if !previous.IsZero() {
+			text += fmt.Sprintf("\nDados em uso de há %s", formatDuration(time.Since(previous)))
+		}
+		return text
+	}
+
+	hosts, groups := inv.Size()
+	text := fmt.Sprintf("✅ %d hosts e %d grupos atualizados", hosts, groups)
+	if !previous.IsZero() {
+		text += fmt.Sprintf(" (dados anteriores de há %s)", formatDuration(time.Since(previous)))
+	}
+	return text
+}
+
+// inventoryNote informa a idade dos dados quando a resposta veio do inventário
+func inventoryNote(z *zabbix.Client) string {
+	age, ok := z.InventoryAge()
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("\n🕒 Dados do inventário de há %s (/zbx_refresh para atualizar)", formatDuration(age))
+}
@@ -0,0 +1,255 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// latestMaxText limita o tamanho dos valores de texto exibidos no /latest
+const latestMaxText = 200
+
+func (b *Bot) handleLatest(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /latest SRV01 ou /latest SRV01 vfs.fs
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /latest <host> [filtro de key]\nExemplos:\n/latest SRV01\n/latest SRV01 vfs.fs.size[*,pused]"))
+		return
+	}
+	hostName := parts[1]
+	keySearch := strings.Join(parts[2:], " ")
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	host, items, err := b.hostItems(ctx, z, hostName, keySearch)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()))
+		return
+	}
+	if len(items) == 0 {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Nenhum item encontrado em %s.", host.Host)))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📋 Últimos dados de %s", host.Host))
+	if keySearch != "" {
+		sb.WriteString(fmt.Sprintf(" (%s)", keySearch))
+	}
+	sb.WriteString(fmt.Sprintf(" - %d itens\n\n", len(items)))
+
+	for _, item := range items {
+		sb.WriteString(fmt.Sprintf("• %s: %s", item.Name, formatLatestValue(item, item.Lastvalue)))
+		if change := formatChange(item); change != "" {
+			sb.WriteString(" (" + change + ")")
+		}
+		sb.WriteString(fmt.Sprintf("\n   %s · %s\n", item.Key, formatLastCheck(item)))
+	}
+
+	for _, part := range splitMessage(sb.String()) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+}
+
+func (b *Bot) handleItem(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /item SRV01 system.cpu.util
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 3 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /item <host> <key>\nExemplo: /item SRV01 system.cpu.util"))
+		return
+	}
+	hostName, key := parts[1], strings.Join(parts[2:], " ")
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	host, items, err := b.hostItems(ctx, z, hostName, key)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()))
+		return
+	}
+
+	// A busca é por trecho da key: prefere a key exata e só aceita outra se for a única
+	var item zabbix.Item
+	found := false
+	for _, i := range items {
+		if i.Key == key {
+			item, found = i, true
+			break
+		}
+	}
+	if !found && len(items) == 1 {
+		item, found = items[0], true
+	}
+
+	if !found {
+		if len(items) == 0 {
+			b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Item %s não encontrado em %s.", key, host.Host)))
+			return
+		}
+		text := fmt.Sprintf("🔍 %d itens contêm %s em %s. Informe a key completa:\n\n", len(items), key, host.Host)
+		for _, i := range items {
+			text += fmt.Sprintf("• %s (%s)\n", i.Key, i.Name)
+		}
+		for _, part := range splitMessage(text) {
+			b.API.Send(tgbotapi.NewMessage(chatID, part))
+		}
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📌 %s\n\n", item.Name))
+	sb.WriteString(fmt.Sprintf("Host: %s\n", host.Host))
+	sb.WriteString(fmt.Sprintf("Key: %s\n", item.Key))
+	sb.WriteString(fmt.Sprintf("Valor: %s\n", formatLatestValue(item, item.Lastvalue)))
+	if item.Prevvalue != "" {
+		sb.WriteString(fmt.Sprintf("Anterior: %s\n", formatLatestValue(item, item.Prevvalue)))
+	}
+	if change := formatChange(item); change != "" {
+		sb.WriteString(fmt.Sprintf("Variação: %s\n", change))
+	}
+	if last := item.LastCheck(); !last.IsZero() {
+		sb.WriteString(fmt.Sprintf("Última coleta: %s (%s)\n", last.Format("02/01/2006 15:04:05"), formatLastCheck(item)))
+	} else {
+		sb.WriteString("Última coleta: nunca\n")
+	}
+	if item.Numeric() {
+		sb.WriteString(fmt.Sprintf("\n📈 /history %s %s", host.Host, item.Key))
+	}
+
+	b.API.Send(tgbotapi.NewMessage(chatID, sb.String()))
+}
+
+// hostItems resolve o host pelo nome e busca seus itens cuja key contém keySearch
+func (b *Bot) hostItems(ctx context.Context, z *zabbix.Client, hostName, keySearch string) (zabbix.Host, []zabbix.Item, error) {
+	host, ok, err := z.GetHostByName(ctx, hostName)
+	if err != nil {
+		log.Println(err)
+		return host, nil, fmt.Errorf("Erro ao consultar Zabbix:\n%v", err)
+	}
+	if !ok {
+		return host, nil, fmt.Errorf("Host %s não encontrado.", hostName)
+	}
+
+	items, err := z.GetItems(ctx, []string{host.Hostid}, keySearch)
+	if err != nil {
+		log.Println(err)
+		return host, nil, fmt.Errorf("Erro ao consultar Zabbix:\n%v", err)
+	}
+	return host, items, nil
+}
+
+// formatLatestValue formata o valor bruto do item de acordo com as unidades do Zabbix
+func formatLatestValue(item zabbix.Item, raw string) string {
+	if item.LastCheck().IsZero() {
+		return "sem dados"
+	}
+	if !item.Numeric() {
+		raw = strings.TrimSpace(raw)
+		if len([]rune(raw)) > latestMaxText {
+			raw = string([]rune(raw)[:latestMaxText]) + "…"
+		}
+		return raw
+	}
+
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return raw
+	}
+	return formatUnits(v, item.Units)
+}
+
+// formatUnits trata as unidades especiais do Zabbix (B, bps, s, uptime, unixtime)
+func formatUnits(v float64, units string) string {
+	switch units {
+	case "B", "Bps":
+		return scaleValue(v, 1024, units)
+	case "bps":
+		return scaleValue(v, 1000, units)
+	case "uptime":
+		return formatDuration(time.Duration(v) * time.Second)
+	case "s":
+		if math.Abs(v) < 1 {
+			return fmt.Sprintf("%.1fms", v*1000)
+		}
+		return formatDuration(time.Duration(v * float64(time.Second)))
+	case "unixtime":
+		return time.Unix(int64(v), 0).Format("02/01/2006 15:04:05")
+	case "":
+		return formatItemValue(v, "")
+	}
+	return formatItemValue(v, " "+units)
+}
+
+// scaleValue aplica os prefixos K, M, G e T, ex: 1536 B -> 1.50 KB
+func scaleValue(v float64, base float64, units string) string {
+	prefixes := []string{"", "K", "M", "G", "T"}
+	i := 0
+	for math.Abs(v) >= base && i < len(prefixes)-1 {
+		v /= base
+		i++
+	}
+	if i == 0 {
+		return formatItemValue(v, " "+units)
+	}
+	return fmt.Sprintf("%.2f %s%s", v, prefixes[i], units)
+}
+
+// formatChange descreve a variação do último valor em relação ao anterior
+func formatChange(item zabbix.Item) string {
+	if item.Prevvalue == "" || item.LastCheck().IsZero() {
+		return ""
+	}
+	if !item.Numeric() {
+		if item.Prevvalue != item.Lastvalue {
+			return "alterado"
+		}
+		return ""
+	}
+
+	last, err1 := strconv.ParseFloat(item.Lastvalue, 64)
+	prev, err2 := strconv.ParseFloat(item.Prevvalue, 64)
+	if err1 != nil || err2 != nil {
+		return ""
+	}
+
+	delta := last - prev
+	switch {
+	case delta > 0:
+		return "▲ +" + formatUnits(delta, deltaUnits(item.Units))
+	case delta < 0:
+		return "▼ -" + formatUnits(-delta, deltaUnits(item.Units))
+	}
+	return "= estável"
+}
+
+// deltaUnits evita formatar a diferença entre dois horários como uma data
+func deltaUnits(units string) string {
+	if units == "unixtime" {
+		return "s"
+	}
+	return units
+}
+
+// formatLastCheck informa há quanto tempo o item foi coletado
+func formatLastCheck(item zabbix.Item) string {
+	last := item.LastCheck()
+	if last.IsZero() {
+		return "nunca coletado"
+	}
+	return "há " + formatDuration(time.Since(last))
+}
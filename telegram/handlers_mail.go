@@ -12,7 +12,10 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func (b *Bot) handleSendMailCounter(update tgbotapi.Update) {
+// mailFrom é o remetente dos emails enviados pelo bot
+const mailFrom = "telegram.bot@lapavermelha.com.br"
+
+func (b *Bot) handleSendMailCounter(update tgbotapi.Update, target zabbixTarget) {
 	parts := strings.Split(update.Message.Text, " ")
 	if len(parts) < 2 {
 		msg := tgbotapi.NewMessage(update.Message.Chat.ID, "Uso: /send_mail_counter <email1> [email2] ...")
@@ -26,8 +29,12 @@ func (b *Bot) handleSendMailCounter(update tgbotapi.Update) {
 	processingMsg := tgbotapi.NewMessage(update.Message.Chat.ID, "⏳ Coletando dados das impressoras...")
 	tempMsg, _ := b.API.Send(processingMsg)
 
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
 	// Obtém contadores das impressoras
-	printers, err := monitor.GetPrintersCounter(b.Zabbix)
+	printers, err := monitor.GetPrintersCounter(ctx, z)
 	if err != nil {
 		errorMsg := fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)
 		edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, errorMsg)
@@ -60,7 +67,7 @@ func (b *Bot) handleSendMailCounter(update tgbotapi.Update) {
 
 	// Prepara email
 	emailMsg := mailer.EmailMessage{
-		From:        "telegram.bot@lapavermelha.com.br",
+		From:        mailFrom,
 		To:          emails,
 		Subject:     "Relatório de Contadores de Impressoras",
 		HTMLBody:    htmlBody,
@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func (b *Bot) handleMaintenanceAdd(update tgbotapi.Update, server zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /maintenance_add SRV01 2h Atualização do Windows
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 3 {
+		msg := tgbotapi.NewMessage(chatID, "Uso: /maintenance_add <host|grupo> <duração> [descrição]\nExemplo: /maintenance_add SRV01 2h Atualização do Windows")
+		b.API.Send(msg)
+		return
+	}
+
+	target := parts[1]
+	duration, err := parseDuration(parts[2])
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()+"\nExemplos: 30m, 2h, 1d"))
+		return
+	}
+
+	description := strings.Join(parts[3:], " ")
+	user := "desconhecido"
+	if update.Message.From != nil {
+		user = userDisplayName(update.Message.From)
+	}
+	if description == "" {
+		description = "Manutenção criada via Telegram"
+	}
+	description += " — " + user
+
+	processingMsg := tgbotapi.NewMessage(chatID, fmt.Sprintf("⏳ Criando manutenção para %s...", target))
+	tempMsg, _ := b.API.Send(processingMsg)
+
+	z := server.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	req := zabbix.MaintenanceRequest{
+		Description: description,
+		Start:       time.Now(),
+		Duration:    duration,
+	}
+
+	// O alvo pode ser um host ou um grupo; host tem prioridade
+	host, ok, err := z.GetHostByName(ctx, target)
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+	if ok {
+		req.HostIDs = []string{host.Hostid}
+	} else {
+		groupID, err := z.GroupID(ctx, target)
+		if err != nil {
+			b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Nenhum host ou grupo encontrado com o nome %s.", target)))
+			return
+		}
+		req.GroupIDs = []string{groupID}
+	}
+
+	// O nome precisa ser único no Zabbix
+	req.Name = fmt.Sprintf("Telegram: %s %s", target, req.Start.Format("02/01 15:04:05"))
+
+	id, err := z.CreateMaintenance(ctx, req)
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao criar manutenção:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	text := fmt.Sprintf("🛠️ Manutenção criada!\nID: %s\nAlvo: %s\nAté: %s",
+		id, target, req.Start.Add(duration).Format("02/01/2006 15:04"))
+	b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, text))
+}
+
+func (b *Bot) handleMaintenanceList(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	processingMsg := tgbotapi.NewMessage(chatID, "⏳ Consultando manutenções no Zabbix...")
+	tempMsg, _ := b.API.Send(processingMsg)
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	maintenances, err := z.GetMaintenances(ctx)
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	now := time.Now()
+	var sb strings.Builder
+	sb.WriteString("🛠️🛠️🛠️ Manutenções 🛠️🛠️🛠️\n\n")
+
+	count := 0
+	for _, m := range maintenances {
+		// Manutenções encerradas não interessam aqui
+		if !m.ActiveTill.After(now) {
+			continue
+		}
+		count++
+
+		state := "🕒 Agendada"
+		if m.Active(now) {
+			state = "🟢 Ativa"
+		}
+
+		sb.WriteString(fmt.Sprintf("• ID: %s — %s\n", m.ID, state))
+		sb.WriteString(fmt.Sprintf("%s\n", m.Name))
+		if len(m.Hosts) > 0 {
+			sb.WriteString(fmt.Sprintf("Hosts: %s\n", strings.Join(m.Hosts, ", ")))
+		}
+		if len(m.Groups) > 0 {
+			sb.WriteString(fmt.Sprintf("Grupos: %s\n", strings.Join(m.Groups, ", ")))
+		}
+		sb.WriteString(fmt.Sprintf("%s até %s\n\n", m.ActiveSince.Format("02/01 15:04"), m.ActiveTill.Format("02/01 15:04")))
+	}
+
+	if count == 0 {
+		sb.WriteString("Nenhuma manutenção ativa ou agendada.")
+	}
+
+	b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, sb.String()))
+}
+
+func (b *Bot) handleMaintenanceRemove(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) != 2 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /maintenance_remove <ID>"))
+		return
+	}
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	if err := z.DeleteMaintenance(ctx, parts[1]); err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao remover manutenção:\n%v", err)))
+		log.Println(err)
+		return
+	}
+
+	b.API.Send(tgbotapi.NewMessage(chatID, "Manutenção removida!"))
+}
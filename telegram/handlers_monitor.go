@@ -0,0 +1,229 @@
+package bot
+
+import (
+	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// monitorAddUsage explica os tipos de checagem do /monitor_add
+const monitorAddUsage = "Uso: /monitor_add <minutos> <tipo> [opções]\n\n" +
+	"Tipos:\n" +
+	"• ping - Hosts offline (o mesmo do /status_monitor)\n" +
+	"• protheus - Serviços do Protheus parados\n" +
+	"• toner [limite %] - Impressoras com toner abaixo do limite (padrão: 10)\n" +
+	"• item <host> <key> <condição> - Itens de um host, ex: > 90, <= 5, != 0\n\n" +
+	"Exemplos:\n" +
+	"/monitor_add 5 ping\n" +
+	"/monitor_add 10 protheus\n" +
+	"/monitor_add 60 toner 15\n" +
+	"/monitor_add 5 item SRV01 system.cpu.util > 90\n" +
+	"/monitor_add 30 item SRV01 vfs.fs.size[*,pused] >= 95"
+
+func (b *Bot) handleStatusMonitor(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /status_monitor 5   (5 minutos)
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		msg := tgbotapi.NewMessage(chatID, "Uso: /status_monitor <minutos>\nExemplo: /status_monitor 5\n\nPara outras checagens, veja /monitor_add.")
+		b.API.Send(msg)
+		return
+	}
+
+	b.createMonitor(update.Message, target.client, parts[1], monitor.PingCheck{})
+}
+
+func (b *Bot) handleMonitorAdd(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /monitor_add 5 item SRV01 system.cpu.util > 90
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 3 {
+		b.API.Send(tgbotapi.NewMessage(chatID, monitorAddUsage))
+		return
+	}
+
+	check, err := parseMonitorCheck(parts[2], parts[3:])
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()+"\n\n"+monitorAddUsage))
+		return
+	}
+	b.createMonitor(update.Message, target.client, parts[1], check)
+}
+
+// parseMonitorCheck monta a checagem do tipo informado a partir das opções do comando
+func parseMonitorCheck(kind string, args []string) (monitor.Check, error) {
+	spec := monitor.Spec{Kind: strings.ToLower(kind)}
+
+	switch spec.Kind {
+	case monitor.KindPing, monitor.KindProtheus:
+	case monitor.KindToner:
+		if len(args) > 0 {
+			threshold, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "%"), 64)
+			if err != nil || threshold <= 0 || threshold > 100 {
+				return nil, fmt.Errorf("limite de toner inválido: %s", args[0])
+			}
+			spec.Threshold = threshold
+		}
+	case monitor.KindItem:
+		if len(args) < 3 {
+			return nil, fmt.Errorf("informe o host, a key e a condição do item")
+		}
+		spec.Host, spec.Key, spec.Condition = args[0], args[1], strings.Join(args[2:], " ")
+	default:
+		return nil, fmt.Errorf("tipo de checagem desconhecido: %s", kind)
+	}
+
+	return monitor.NewCheck(spec)
+}
+
+// createMonitor inicia um monitor no servidor do chat (ou do --server do comando)
+func (b *Bot) createMonitor(msg *tgbotapi.Message, z *zabbix.Client, interval string, check monitor.Check) {
+	chatID := msg.Chat.ID
+
+	minutes, err := strconv.Atoi(interval)
+	if err != nil || minutes <= 0 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Intervalo inválido. Informe um número inteiro de minutos."))
+		return
+	}
+
+	for _, m := range b.chatMonitors(chatID) {
+		if m.zabbix == z && sameSpec(m.check.Spec(), check.Spec()) {
+			b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Já existe um monitor igual neste chat: #%d. Veja /monitor_list.", m.ID)))
+			return
+		}
+	}
+
+	m := NewMonitor(b.monitorStore.nextID(), chatID, minutes, z, check)
+	b.monitorStore.put(m)
+	b.startMonitor(m)
+
+	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Monitor #%d iniciado: %s%s, checagem a cada %d minutos. Vou avisar quando surgir um problema e quando ele se resolver.", m.ID, check.Describe(), b.serverLabel(z), minutes)))
+}
+
+// sameSpec compara duas definições de checagem
+func sameSpec(a, b monitor.Spec) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func (b *Bot) handleMonitorList(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	monitors := b.chatMonitors(chatID)
+	if len(monitors) == 0 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Nenhum monitor em execução neste chat. Crie um com /status_monitor ou /monitor_add."))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("📡 Monitores deste chat\n\n")
+	for _, m := range monitors {
+		m.mu.Lock()
+		c := m.counts()
+		fmt.Fprintf(&sb, "#%d %s%s\n", m.ID, m.check.Describe(), b.serverLabel(m.zabbix))
+		fmt.Fprintf(&sb, "   A cada %d min", m.IntervalMinutes)
+		if !m.lastCheck.IsZero() {
+			fmt.Fprintf(&sb, ", última às %s", m.lastCheck.Format("15:04"))
+		}
+		fmt.Fprintf(&sb, "\n   ✅ %d  ❌ %d", c.ok, c.down)
+		if c.pending > 0 {
+			fmt.Fprintf(&sb, "  ⏳ %d", c.pending)
+		}
+		if m.lastErr != nil {
+			sb.WriteString("  ⚠️ falha na última checagem")
+		}
+		sb.WriteString("\n\n")
+		m.mu.Unlock()
+	}
+	sb.WriteString("Pare um monitor com /monitor_stop <ID>.")
+
+	for _, part := range splitMessage(sb.String()) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+}
+
+func (b *Bot) handleMonitorStop(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /monitor_stop 3
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /monitor_stop <ID>\nVeja os IDs com /monitor_list."))
+		return
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(parts[1], "#"), 10, 64)
+	m := b.chatMonitor(chatID, id)
+	if err != nil || m == nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Monitor %s não encontrado neste chat. Veja /monitor_list.", parts[1])))
+		return
+	}
+
+	b.stopMonitor(m)
+	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Monitor #%d parado.", m.ID)))
+}
+
+// startMonitor registra o monitor e inicia as checagens
+func (b *Bot) startMonitor(m *Monitor) {
+	b.mu.Lock()
+	b.Monitors[m.ID] = m
+	b.mu.Unlock()
+	go m.run(b)
+}
+
+// stopMonitor para o monitor, remove os botões do resumo e o apaga do disco
+func (b *Bot) stopMonitor(m *Monitor) {
+	m.stop()
+
+	b.mu.Lock()
+	delete(b.Monitors, m.ID)
+	b.mu.Unlock()
+	b.monitorStore.remove(m.ID)
+
+	m.mu.Lock()
+	msgID := m.summaryMsgID
+	var down []transition
+	for id, state := range m.states {
+		if state.Down {
+			down = append(down, transition{id: id, state: *state})
+		}
+	}
+	m.mu.Unlock()
+	m.closeOutages(b, down, time.Now())
+
+	if msgID != 0 {
+		b.API.Send(tgbotapi.NewEditMessageReplyMarkup(m.ChatID, msgID, tgbotapi.InlineKeyboardMarkup{}))
+	}
+}
+
+// chatMonitor busca um monitor do chat pelo ID
+func (b *Bot) chatMonitor(chatID, id int64) *Monitor {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if m, ok := b.Monitors[id]; ok && m.ChatID == chatID {
+		return m
+	}
+	return nil
+}
+
+// chatMonitors devolve os monitores do chat, em ordem de ID
+func (b *Bot) chatMonitors(chatID int64) []*Monitor {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var list []*Monitor
+	for _, m := range b.Monitors {
+		if m.ChatID == chatID {
+			list = append(list, m)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// muteUntilRecovery é a duração do botão e do /mute que silencia até o host se recuperar
+const muteUntilRecovery = "volta"
+
+// parseMuteDuration interpreta a duração do silêncio; zero silencia até a recuperação
+func parseMuteDuration(value string) (time.Duration, error) {
+	if strings.EqualFold(value, muteUntilRecovery) {
+		return 0, nil
+	}
+	return parseDuration(value)
+}
+
+func (b *Bot) handleMute(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /mute SRV01 4h ou /mute SRV01 volta
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 3 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /mute <host> <duração|volta>\nExemplos:\n/mute SRV01 4h\n/mute SRV01 2d\n/mute SRV01 volta (até o host se recuperar)"))
+		return
+	}
+
+	duration, err := parseMuteDuration(parts[2])
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()))
+		return
+	}
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	host, ok, err := z.GetHostByName(ctx, parts[1])
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		return
+	}
+	if !ok {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Host %s não encontrado.", parts[1])))
+		return
+	}
+
+	mute := b.muteHost(chatID, host.Host, duration, update.Message.From)
+	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("🔕 Avisos dos monitores para %s silenciados %s.\nO host continua aparecendo no /status_check. Veja /mutes.", host.Host, mute.describe())))
+}
+
+// muteHost silencia o host no chat pela duração informada (zero: até a recuperação)
+func (b *Bot) muteHost(chatID int64, host string, duration time.Duration, user *tgbotapi.User) hostMute {
+	now := time.Now()
+	mute := hostMute{Host: host, By: userDisplayName(user), CreatedAt: now}
+	if duration > 0 {
+		mute.Until = now.Add(duration)
+	}
+	b.muteStore.set(chatID, mute)
+	return mute
+}
+
+func (b *Bot) handleUnmute(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /unmute SRV01
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /unmute <host>\nVeja os hosts silenciados com /mutes."))
+		return
+	}
+
+	if !b.muteStore.remove(chatID, parts[1]) {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("%s não está silenciado neste chat.", parts[1])))
+		return
+	}
+	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("🔔 Avisos para %s reativados.", parts[1])))
+}
+
+func (b *Bot) handleMutes(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	mutes := b.muteStore.list(chatID)
+	if len(mutes) == 0 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Nenhum host silenciado neste chat."))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🔕 Hosts silenciados neste chat\n\n")
+	for _, m := range mutes {
+		fmt.Fprintf(&sb, "• %s, %s\n   por %s em %s\n", m.Host, m.describe(), m.By, m.CreatedAt.Format("02/01 15:04"))
+	}
+	sb.WriteString("\nReative os avisos com /unmute <host>.")
+
+	for _, part := range splitMessage(sb.String()) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+}
+
+// handleMuteCallback trata os botões de silêncio dos avisos dos monitores.
+// Formato: mute:<ID do monitor>:<ID do resultado>:<1h|4h|volta>
+func (b *Bot) handleMuteCallback(query *tgbotapi.CallbackQuery, args []string) {
+	chatID := query.Message.Chat.ID
+	if len(args) != 3 {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+		return
+	}
+
+	duration, err := parseMuteDuration(args[2])
+	if err != nil {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Duração inválida."))
+		return
+	}
+
+	var host string
+	if id, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+		if m := b.chatMonitor(chatID, id); m != nil {
+			m.mu.Lock()
+			if state, ok := m.states[args[1]]; ok {
+				host = state.Host
+			}
+			m.mu.Unlock()
+		}
+	}
+	if host == "" {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Host não está mais sendo monitorado."))
+		return
+	}
+
+	mute := b.muteHost(chatID, host, duration, query.From)
+	b.API.Request(tgbotapi.NewCallback(query.ID, fmt.Sprintf("%s silenciado %s.", host, mute.describe())))
+	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("🔕 %s silenciou os avisos de %s %s.", mute.By, host, mute.describe())))
+}
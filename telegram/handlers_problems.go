@@ -0,0 +1,254 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// problemsPageSize é a quantidade de problemas exibidos por página no /problems
+const problemsPageSize = 10
+
+// problemView guarda o último resultado do /problems de um chat para a paginação
+type problemView struct {
+	Problems    []zabbix.Problem
+	Description string
+	FetchedAt   time.Time
+	Filter      zabbix.ProblemFilter
+	Group       string           // resolvido em cada servidor, pois os IDs são diferentes
+	Servers     []*zabbix.Client // servidores consultados (mais de um com /zbx_use todos)
+}
+
+func (b *Bot) handleProblems(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /problems sev=alta group=Servidores age=2h
+	options, _ := parseOptions(strings.Fields(update.Message.Text)[1:])
+
+	processingMsg := tgbotapi.NewMessage(chatID, "⏳ Consultando problemas no Zabbix...")
+	tempMsg, _ := b.API.Send(processingMsg)
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	var filter zabbix.ProblemFilter
+	var description []string
+	group := ""
+
+	if sev, ok := options["sev"]; ok {
+		severity, err := zabbix.ParseSeverity(sev)
+		if err != nil {
+			b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, "❌ "+err.Error()+"\nUse 0-5 ou: informacao, atencao, media, alta, desastre"))
+			return
+		}
+		filter.MinSeverity = severity
+		description = append(description, "severidade ≥ "+zabbix.SeverityName(severity))
+	}
+
+	if g, ok := options["group"]; ok {
+		group = g
+		description = append(description, "grupo "+group)
+	}
+
+	if age, ok := options["age"]; ok {
+		maxAge, err := parseDuration(age)
+		if err != nil {
+			b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, "❌ "+err.Error()+"\nExemplos: age=30m, age=2h, age=1d"))
+			return
+		}
+		filter.MaxAge = maxAge
+		description = append(description, "últimos "+formatDuration(maxAge))
+	}
+
+	view := &problemView{
+		Description: strings.Join(description, ", "),
+		Filter:      filter,
+		Group:       group,
+		Servers:     b.zabbixTargets(target),
+	}
+
+	problems, err := fetchProblems(ctx, view)
+	if err != nil {
+		errorMsg := fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, errorMsg))
+		log.Println(err)
+		return
+	}
+	view.Problems = problems
+	view.FetchedAt = time.Now()
+
+	b.mu.Lock()
+	b.problemViews[chatID] = view
+	b.mu.Unlock()
+
+	text, markup := b.renderProblemsPage(view, 0)
+	edit := tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, text)
+	if markup != nil {
+		edit.ReplyMarkup = markup
+	}
+	b.API.Send(edit)
+}
+
+// handleProblemsCallback trata os botões de paginação do /problems.
+// Formatos: problems:page:<n> e problems:refresh:<n>
+func (b *Bot) handleProblemsCallback(query *tgbotapi.CallbackQuery, args []string) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	b.mu.Lock()
+	view, ok := b.problemViews[chatID]
+	b.mu.Unlock()
+
+	if !ok || len(args) < 2 {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Consulta expirada. Envie /problems novamente."))
+		return
+	}
+
+	page, _ := strconv.Atoi(args[1])
+
+	if args[0] == "refresh" {
+		ctx, cancel := b.zabbixContext()
+		defer cancel()
+
+		problems, err := fetchProblems(ctx, view)
+		if err != nil {
+			b.API.Request(tgbotapi.NewCallback(query.ID, "Erro ao consultar Zabbix."))
+			log.Println(err)
+			return
+		}
+
+		b.mu.Lock()
+		view.Problems = problems
+		view.FetchedAt = time.Now()
+		b.mu.Unlock()
+	}
+
+	b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+
+	text, markup := b.renderProblemsPage(view, page)
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	if markup != nil {
+		edit.ReplyMarkup = markup
+	}
+	b.API.Send(edit)
+}
+
+// fetchProblems consulta os problemas em cada servidor da consulta e junta o resultado,
+// do mais recente para o mais antigo. Só falha se nenhum servidor responder; as falhas
+// parciais vão apenas para o log.
+func fetchProblems(ctx context.Context, view *problemView) ([]zabbix.Problem, error) {
+	var problems []zabbix.Problem
+	var errs []error
+
+	for _, z := range view.Servers {
+		filter := view.Filter
+		if view.Group != "" {
+			groupID, err := z.GroupID(ctx, view.Group)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", z.Name, err))
+				continue
+			}
+			filter.GroupIDs = []string{groupID}
+		}
+
+		list, err := z.GetProblems(ctx, filter)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", z.Name, err))
+			continue
+		}
+		problems = append(problems, list...)
+	}
+
+	if len(errs) > 0 && len(errs) == len(view.Servers) {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		log.Printf("Erro ao consultar problemas: %v", err)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Started.After(problems[j].Started)
+	})
+	return problems, nil
+}
+
+// renderProblemsPage monta o texto e os botões de uma página do /problems
+func (b *Bot) renderProblemsPage(view *problemView, page int) (string, *tgbotapi.InlineKeyboardMarkup) {
+	total := len(view.Problems)
+	pages := max((total+problemsPageSize-1)/problemsPageSize, 1)
+	page = min(max(page, 0), pages-1)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🚨🚨🚨 Problemas ativos (%d) 🚨🚨🚨\n", total))
+	if view.Description != "" {
+		sb.WriteString(fmt.Sprintf("Filtros: %s\n", view.Description))
+	}
+	sb.WriteString(fmt.Sprintf("Atualizado às %s\n\n", view.FetchedAt.Format("15:04:05")))
+
+	if total == 0 {
+		sb.WriteString("Nenhum problema encontrado. ✅")
+	}
+
+	start := page * problemsPageSize
+	end := min(start+problemsPageSize, total)
+
+	// Um botão por problema abre a mensagem com as ações de ack
+	var actionRows [][]tgbotapi.InlineKeyboardButton
+	var actionRow []tgbotapi.InlineKeyboardButton
+	for i, p := range view.Problems[start:end] {
+		sb.WriteString(fmt.Sprintf("#%d %s\n\n", start+i+1, b.renderProblem(p)))
+
+		label := fmt.Sprintf("⚙️ %d", start+i+1)
+		actionRow = append(actionRow, tgbotapi.NewInlineKeyboardButtonData(label, "event:show:"+b.serverRef(p.Server, p.EventID)))
+		if len(actionRow) == 5 {
+			actionRows = append(actionRows, actionRow)
+			actionRow = nil
+		}
+	}
+	if len(actionRow) > 0 {
+		actionRows = append(actionRows, actionRow)
+	}
+
+	if pages > 1 {
+		sb.WriteString(fmt.Sprintf("Página %d/%d", page+1, pages))
+	}
+
+	var row []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("◀️ Anterior", fmt.Sprintf("problems:page:%d", page-1)))
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData("🔄 Atualizar", fmt.Sprintf("problems:refresh:%d", page)))
+	if page < pages-1 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("Próxima ▶️", fmt.Sprintf("problems:page:%d", page+1)))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(append(actionRows, row)...)
+	return sb.String(), &markup
+}
+
+// renderProblem formata um problema com severidade, hosts, duração e estado do ack
+func (b *Bot) renderProblem(p zabbix.Problem) string {
+	ack := "⚠️ Não reconhecido"
+	if p.Acknowledged {
+		ack = "✅ Reconhecido"
+	}
+
+	hosts := strings.Join(p.Hosts, ", ")
+	if b.Servers.Multiple() {
+		hosts += " [" + p.Server + "]"
+	}
+
+	return fmt.Sprintf("%s %s • %s\n%s\n⏱️ %s • %s",
+		severityIcon(p.Severity), zabbix.SeverityName(p.Severity), hosts,
+		p.Name,
+		formatDuration(time.Since(p.Started)), ack,
+	)
+}
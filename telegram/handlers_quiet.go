@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"LapaTelegramBot/config"
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const notifyWindowUsage = "Uso:\n" +
+	"/notify_window 08:00-18:00 [dias] - Avisar só nesse horário\n" +
+	"/notify_window seg-sex - Avisar o dia todo, só nesses dias\n" +
+	"/notify_window grupos <grupo1,grupo2|off> - Grupos avisados a qualquer hora\n" +
+	"/notify_window severidade <severidade|off> - Alertas do Zabbix avisados a qualquer hora a partir da severidade\n" +
+	"/notify_window off - Avisar a qualquer hora\n\n" +
+	"Dias: dom, seg, ter, qua, qui, sex, sab, em faixas (seg-sex) ou listas (seg,qua,sex)."
+
+func (b *Bot) handleNotifyWindow(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplos de uso: /notify_window 08:00-18:00 seg-sex, /notify_window grupos Servidores
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		b.showNotifyWindow(chatID)
+		return
+	}
+
+	switch strings.ToLower(parts[1]) {
+	case "off":
+		digest, ok := b.quietStore.remove(chatID)
+		if !ok {
+			b.API.Send(tgbotapi.NewMessage(chatID, "Este chat não tem janela de avisos."))
+			return
+		}
+		b.API.Send(tgbotapi.NewMessage(chatID, "🔔 Janela de avisos removida. Os avisos chegam a qualquer hora."))
+		if len(digest) > 0 {
+			b.sendDigest(chatID, digest)
+		}
+	case "grupos":
+		groups := config.SplitList(strings.Join(parts[2:], " "))
+		if len(groups) == 0 {
+			b.API.Send(tgbotapi.NewMessage(chatID, notifyWindowUsage))
+			return
+		}
+		if strings.EqualFold(groups[0], "off") {
+			groups = nil
+		}
+		if !b.quietStore.update(chatID, false, func(q *quietHours) { q.AlwaysGroups = groups }) {
+			b.API.Send(tgbotapi.NewMessage(chatID, "Defina a janela antes, ex: /notify_window 08:00-18:00 seg-sex"))
+			return
+		}
+		b.showNotifyWindow(chatID)
+	case "severidade":
+		if len(parts) < 3 {
+			b.API.Send(tgbotapi.NewMessage(chatID, notifyWindowUsage))
+			return
+		}
+		severity := 0
+		if !strings.EqualFold(parts[2], "off") {
+			var err error
+			if severity, err = zabbix.ParseSeverity(strings.Join(parts[2:], " ")); err != nil {
+				b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()))
+				return
+			}
+		}
+		if !b.quietStore.update(chatID, false, func(q *quietHours) { q.AlwaysSeverity = severity }) {
+			b.API.Send(tgbotapi.NewMessage(chatID, "Defina a janela antes, ex: /notify_window 08:00-18:00 seg-sex"))
+			return
+		}
+		b.showNotifyWindow(chatID)
+	default:
+		b.setNotifyWindow(chatID, parts[1:])
+	}
+}
+
+// setNotifyWindow define o horário e os dias da janela, mantendo os grupos e a severidade
+func (b *Bot) setNotifyWindow(chatID int64, args []string) {
+	start, end := 0, 24*60
+	var days []time.Weekday
+	var err error
+
+	if strings.Contains(args[0], ":") || strings.IndexAny(args[0], "0123456789") == 0 {
+		if start, end, err = parseWindow(args[0]); err != nil {
+			b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()+"\n\n"+notifyWindowUsage))
+			return
+		}
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		if days, err = parseWeekdays(strings.Join(args, "")); err != nil {
+			b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()+"\n\n"+notifyWindowUsage))
+			return
+		}
+	}
+
+	b.quietStore.update(chatID, true, func(q *quietHours) {
+		q.Start, q.End, q.Days = start, end, days
+	})
+	b.showNotifyWindow(chatID)
+}
+
+// showNotifyWindow mostra a janela de avisos do chat
+func (b *Bot) showNotifyWindow(chatID int64) {
+	q, ok := b.quietStore.get(chatID)
+	if !ok {
+		b.API.Send(tgbotapi.NewMessage(chatID, "🔔 Este chat recebe avisos a qualquer hora.\n\n"+notifyWindowUsage))
+		return
+	}
+
+	var sb strings.Builder
+	state := "fechada, os avisos vão para o resumo"
+	if q.open(time.Now()) {
+		state = "aberta"
+	}
+	fmt.Fprintf(&sb, "🕒 Janela de avisos: %s\nAgora: %s\n", q.describe(), state)
+	if len(q.AlwaysGroups) > 0 {
+		fmt.Fprintf(&sb, "Grupos avisados a qualquer hora: %s\n", strings.Join(q.AlwaysGroups, ", "))
+	}
+	if q.AlwaysSeverity > 0 {
+		fmt.Fprintf(&sb, "Alertas do Zabbix avisados a qualquer hora: %s ou maior\n", zabbix.SeverityName(q.AlwaysSeverity))
+	}
+	if len(q.Digest) > 0 {
+		fmt.Fprintf(&sb, "Avisos aguardando o resumo: %d\n", len(q.Digest))
+	}
+	b.API.Send(tgbotapi.NewMessage(chatID, sb.String()))
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func (b *Bot) handleZabbixScript(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+	if !b.requireAdmin(chatID) {
+		return
+	}
+
+	// Exemplo de uso: /zbx_script SRVLINUX01 ou /zbx_script SRVLINUX01 Reiniciar Apache
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /zbx_script <host> [nome do script]\nExemplo: /zbx_script SRVLINUX01 Reiniciar Apache"))
+		return
+	}
+	scriptName := strings.Join(parts[2:], " ")
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	host, ok, err := z.GetHostByName(ctx, parts[1])
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		log.Println(err)
+		return
+	}
+	if !ok {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Host %s não encontrado.", parts[1])))
+		return
+	}
+
+	scripts, err := z.GetHostScripts(ctx, host.Hostid)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao listar scripts:\n%v", err)))
+		log.Println(err)
+		return
+	}
+	if len(scripts) == 0 {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Nenhum script permitido para %s.\nVerifique ZABBIX_ALLOWED_SCRIPTS e as permissões dos scripts no Zabbix.", host.Host)))
+		return
+	}
+
+	if scriptName != "" {
+		for _, s := range scripts {
+			if strings.EqualFold(s.Name, scriptName) {
+				b.runScript(ctx, z, chatID, host.Hostid, host.Host, s, update.Message.From)
+				return
+			}
+		}
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Script \"%s\" não está disponível para %s.", scriptName, host.Host)))
+	}
+
+	// Sem nome (ou nome inválido): oferece um botão por script permitido
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, s := range scripts {
+		data := fmt.Sprintf("script:run:%s:%s", b.serverRef(z.Name, host.Hostid), s.Scriptid)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("▶️ "+s.Name, data)))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("📜 Scripts disponíveis para %s:", host.Host))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	b.API.Send(msg)
+}
+
+// handleScriptCallback executa o script escolhido. Formato: script:run:<hostid>[@servidor]:<scriptid>
+func (b *Bot) handleScriptCallback(query *tgbotapi.CallbackQuery, args []string) {
+	chatID := query.Message.Chat.ID
+	if len(args) < 3 || args[0] != "run" {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+		return
+	}
+	if !b.AdminChats[chatID] {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Comando restrito aos administradores."))
+		return
+	}
+	z, hostID := b.resolveRef(chatID, args[1])
+	scriptID := args[2]
+
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	// Consulta novamente para garantir que o script continua permitido para o host
+	scripts, err := z.GetHostScripts(ctx, hostID)
+	if err != nil {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Erro ao consultar Zabbix."))
+		log.Println(err)
+		return
+	}
+
+	for _, s := range scripts {
+		if s.Scriptid != scriptID {
+			continue
+		}
+
+		hostName := hostID
+		if details, ok, err := z.GetHostDetails(ctx, hostID); err == nil && ok {
+			hostName = details.Host
+		}
+
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Executando "+s.Name))
+		b.runScript(ctx, z, chatID, hostID, hostName, s, query.From)
+		return
+	}
+
+	b.API.Request(tgbotapi.NewCallback(query.ID, "Script não permitido para este host."))
+}
+
+// runScript executa o script e envia a saída, dividida em várias mensagens se necessário
+func (b *Bot) runScript(ctx context.Context, z *zabbix.Client, chatID int64, hostID, hostName string, s zabbix.Script, user *tgbotapi.User) {
+	tempMsg, _ := b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("⏳ Executando \"%s\" em %s...", s.Name, hostName)))
+	log.Printf("Script %s executado em %s por %s", s.Name, hostName, userDisplayName(user))
+
+	output, err := z.ExecuteScript(ctx, s.Scriptid, hostID)
+	if err != nil {
+		b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("❌ \"%s\" falhou em %s:\n%v", s.Name, hostName, err)))
+		log.Println(err)
+		return
+	}
+
+	b.API.Send(tgbotapi.NewEditMessageText(chatID, tempMsg.MessageID, fmt.Sprintf("✅ \"%s\" executado em %s.", s.Name, hostName)))
+
+	output = strings.TrimSpace(output)
+	if output == "" {
+		b.API.Send(tgbotapi.NewMessage(chatID, "(sem saída)"))
+		return
+	}
+	for _, part := range splitMessage(output) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package bot
+
+import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+// O gerenciador de serviços remoto só existe no Windows. Fora dele, o pacote compila
+// para os testes, e os comandos de serviços apenas avisam.
+
+func (b *Bot) handleRemoteServices(update tgbotapi.Update) {
+	b.API.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "❌ O gerenciamento de serviços só está disponível com o bot rodando no Windows."))
+}
+
+func (b *Bot) handleListServices(update tgbotapi.Update) {
+	b.handleRemoteServices(update)
+}
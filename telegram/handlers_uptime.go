@@ -0,0 +1,293 @@
+package bot
+
+import (
+	"LapaTelegramBot/file_handler"
+	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/uptime"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// uptimePeriod é o período padrão do /uptime
+const uptimePeriod = 30 * 24 * time.Hour
+
+// uptimeListLimit limita as quedas e os hosts listados na mensagem; a planilha tem todos
+const uptimeListLimit = 15
+
+// initUptime carrega o histórico de quedas registrado pelos monitores
+func (b *Bot) initUptime() {
+	b.uptimeStore = uptime.NewStore()
+	if err := b.uptimeStore.Load(); err != nil {
+		log.Printf("⚠️  Erro ao ler o histórico de disponibilidade: %v", err)
+	}
+}
+
+// recordUptime registra no histórico as quedas e recuperações dos hosts vistas pelos
+// monitores de ping. Os demais monitores acompanham serviços e itens, não hosts.
+func (m *Monitor) recordUptime(b *Bot, problems, recovered []transition, now time.Time) {
+	if _, ok := m.check.(monitor.PingCheck); !ok {
+		return
+	}
+	for _, t := range problems {
+		if err := b.uptimeStore.Record(m.zabbix.Name, t.state.Host, true, t.state.Since); err != nil {
+			log.Printf("Erro ao registrar queda de %s: %v", t.state.Host, err)
+		}
+	}
+	for _, t := range recovered {
+		if err := b.uptimeStore.Record(m.zabbix.Name, t.state.Host, false, now); err != nil {
+			log.Printf("Erro ao registrar recuperação de %s: %v", t.state.Host, err)
+		}
+	}
+}
+
+// closeOutages encerra no histórico as quedas dos resultados que o monitor deixou de
+// acompanhar, como um host que entrou em manutenção, ou de todos os hosts fora quando
+// o monitor é parado; sem isso, a queda ficaria em aberto. Hosts que seguem fora em
+// outro monitor de ping do mesmo servidor mantêm a queda em aberto.
+func (m *Monitor) closeOutages(b *Bot, closed []transition, now time.Time) {
+	if _, ok := m.check.(monitor.PingCheck); !ok || len(closed) == 0 {
+		return
+	}
+	down := b.hostsDown(m)
+	for _, t := range closed {
+		if down[strings.ToLower(t.state.Host)] {
+			continue
+		}
+		if err := b.uptimeStore.Record(m.zabbix.Name, t.state.Host, false, now); err != nil {
+			log.Printf("Erro ao registrar o fim da queda de %s: %v", t.state.Host, err)
+		}
+	}
+}
+
+// hostsDown devolve os hosts fora do ar nos outros monitores de ping do servidor de
+// m, pelo nome em minúsculas
+func (b *Bot) hostsDown(m *Monitor) map[string]bool {
+	b.mu.Lock()
+	var others []*Monitor
+	for _, other := range b.Monitors {
+		if other != m && other.zabbix == m.zabbix {
+			others = append(others, other)
+		}
+	}
+	b.mu.Unlock()
+
+	down := make(map[string]bool)
+	for _, other := range others {
+		if _, ok := other.check.(monitor.PingCheck); !ok {
+			continue
+		}
+		other.mu.Lock()
+		for _, state := range other.states {
+			if state.Down {
+				down[strings.ToLower(state.Host)] = true
+			}
+		}
+		other.mu.Unlock()
+	}
+	return down
+}
+
+func (b *Bot) handleUptime(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /uptime SRV01 7d
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Uso: /uptime <host> [período]\nExemplos:\n/uptime SRV01\n/uptime SRV01 7d\n/uptime SRV01 12h\n\nPadrão: últimos 30 dias."))
+		return
+	}
+
+	period := uptimePeriod
+	if len(parts) > 2 {
+		var err error
+		if period, err = parseDuration(parts[2]); err != nil {
+			b.API.Send(tgbotapi.NewMessage(chatID, "❌ "+err.Error()))
+			return
+		}
+	}
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	// Hosts removidos do Zabbix ainda têm histórico: usa o nome informado
+	name := parts[1]
+	if host, ok, err := z.GetHostByName(ctx, name); err == nil && ok {
+		name = host.Host
+	}
+
+	now := time.Now()
+	report := b.uptimeStore.Report(z.Name, name, now.Add(-period), now)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📈 Disponibilidade de %s%s\n", name, b.serverLabel(z))
+	fmt.Fprintf(&sb, "Período: %s a %s (%s)\n\n", report.From.Format("02/01 15:04"), report.To.Format("02/01 15:04"), formatDuration(period))
+	sb.WriteString(formatReport(report))
+
+	if len(report.Outages) > 0 {
+		sb.WriteString("\nÚltimas quedas:\n")
+		for i := len(report.Outages) - 1; i >= 0 && i >= len(report.Outages)-uptimeListLimit; i-- {
+			sb.WriteString(formatOutage(report.Outages[i]) + "\n")
+		}
+	}
+	sb.WriteString(b.uptimeCoverage(report.From))
+
+	for _, part := range splitMessage(sb.String()) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+	if len(report.Outages) > 0 {
+		b.sendAvailabilitySheet(chatID, "DISPONIBILIDADE DE "+strings.ToUpper(name), []uptime.Report{report})
+	}
+}
+
+func (b *Bot) handleAvailability(update tgbotapi.Update, target zabbixTarget) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplos de uso: /availability, /availability Servidores 09/2026
+	args := strings.Fields(update.Message.Text)[1:]
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if len(args) > 0 {
+		if month, ok := parseMonth(args[len(args)-1], now); ok {
+			from = month
+			args = args[:len(args)-1]
+		}
+	}
+	to := from.AddDate(0, 1, 0)
+	if from.After(now) {
+		b.API.Send(tgbotapi.NewMessage(chatID, "❌ O mês informado ainda não começou."))
+		return
+	}
+	group := strings.Join(args, " ")
+
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	scope := "hosts monitorados"
+	hosts, err := z.GetHostsExcludingGroups(ctx, z.Groups.MonitorExclude)
+	if group != "" {
+		scope = "grupo " + group
+		hosts, err = z.GetHostsInGroup(ctx, group)
+	}
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)))
+		return
+	}
+	if len(hosts) == 0 {
+		b.API.Send(tgbotapi.NewMessage(chatID, "Nenhum host encontrado."))
+		return
+	}
+
+	reports := make([]uptime.Report, len(hosts))
+	var total float64
+	for i, h := range hosts {
+		reports[i] = b.uptimeStore.Report(z.Name, h.Host, from, to)
+		total += reports[i].Availability
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		if reports[i].Availability != reports[j].Availability {
+			return reports[i].Availability < reports[j].Availability
+		}
+		return reports[i].Host < reports[j].Host
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📊 Disponibilidade em %s · %s%s\n", from.Format("01/2006"), scope, b.serverLabel(z))
+	fmt.Fprintf(&sb, "Período: %s a %s\n", reports[0].From.Format("02/01 15:04"), reports[0].To.Format("02/01 15:04"))
+	fmt.Fprintf(&sb, "Hosts: %d · média %.2f%%\n\n", len(reports), total/float64(len(reports)))
+
+	withOutages := 0
+	for _, r := range reports {
+		if len(r.Outages) == 0 {
+			continue
+		}
+		withOutages++
+		if withOutages > uptimeListLimit {
+			continue
+		}
+		fmt.Fprintf(&sb, "• %s: %.2f%% · %d queda(s) · fora %s · MTTR %s\n",
+			r.Host, r.Availability, len(r.Outages), formatDuration(r.Downtime), formatDuration(r.MTTR))
+	}
+	if withOutages > uptimeListLimit {
+		fmt.Fprintf(&sb, "... e mais %d com quedas (veja a planilha)\n", withOutages-uptimeListLimit)
+	}
+	if withOutages < len(reports) {
+		fmt.Fprintf(&sb, "✅ %d host(s) sem quedas: 100%%\n", len(reports)-withOutages)
+	}
+	sb.WriteString(b.uptimeCoverage(from))
+
+	for _, part := range splitMessage(sb.String()) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+	b.sendAvailabilitySheet(chatID, fmt.Sprintf("DISPONIBILIDADE %s · %s", from.Format("01/2006"), strings.ToUpper(scope)), reports)
+}
+
+// formatReport formata os indicadores de um relatório de disponibilidade
+func formatReport(r uptime.Report) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "✅ Disponibilidade: %.2f%%\n", r.Availability)
+	fmt.Fprintf(&sb, "📉 Quedas: %d\n", len(r.Outages))
+	if len(r.Outages) == 0 {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "⏱️ Tempo fora: %s\n", formatDuration(r.Downtime))
+	if r.MTTR > 0 {
+		fmt.Fprintf(&sb, "🔧 MTTR: %s\n", formatDuration(r.MTTR))
+	}
+	fmt.Fprintf(&sb, "🕳️ Maior queda: %s (%s)\n", formatDuration(r.Longest.Duration()), r.Longest.Start.Format("02/01 15:04"))
+	return sb.String()
+}
+
+func formatOutage(o uptime.Outage) string {
+	if o.Ongoing {
+		return fmt.Sprintf("• %s → em andamento (%s)", o.Start.Format("02/01 15:04"), formatDuration(o.Duration()))
+	}
+	return fmt.Sprintf("• %s → %s (%s)", o.Start.Format("02/01 15:04"), o.End.Format("02/01 15:04"), formatDuration(o.Duration()))
+}
+
+// uptimeCoverage avisa quando o histórico começa depois do início do período
+func (b *Bot) uptimeCoverage(from time.Time) string {
+	since := b.uptimeStore.Since()
+	if !since.IsZero() && !since.After(from) {
+		return ""
+	}
+	if since.IsZero() {
+		return "\nℹ️ Nenhuma queda registrada ainda. O histórico é gravado pelos monitores de ping (/status_monitor)."
+	}
+	return fmt.Sprintf("\nℹ️ Histórico registrado desde %s: antes disso, os hosts contam como disponíveis.", since.Format("02/01/2006 15:04"))
+}
+
+// parseMonth interpreta o mês como "09/2026", "2026-09" ou "09" (no ano atual)
+func parseMonth(value string, now time.Time) (time.Time, bool) {
+	for _, layout := range []string{"01/2006", "1/2006", "2006-01"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, true
+		}
+	}
+	if month, err := strconv.Atoi(value); err == nil && month >= 1 && month <= 12 {
+		return time.Date(now.Year(), time.Month(month), 1, 0, 0, 0, 0, now.Location()), true
+	}
+	return time.Time{}, false
+}
+
+// sendAvailabilitySheet gera e envia a planilha de disponibilidade
+func (b *Bot) sendAvailabilitySheet(chatID int64, title string, reports []uptime.Report) {
+	excelFile, err := file_handler.GenerateAvailabilitySheet(title, reports)
+	if err != nil {
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Erro ao gerar planilha:\n%v", err)))
+		log.Printf("Erro ao gerar planilha: %v", err)
+		return
+	}
+	defer os.Remove(excelFile)
+
+	b.API.Send(tgbotapi.NewDocument(chatID, tgbotapi.FilePath(excelFile)))
+}
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/uptime"
+	"LapaTelegramBot/zabbix"
+	"testing"
+	"time"
+)
+
+// uptimeBot devolve um bot com o histórico de quedas em um diretório temporário
+func uptimeBot(t *testing.T) *Bot {
+	t.Chdir(t.TempDir())
+	return &Bot{
+		Monitors:     make(map[int64]*Monitor),
+		monitorStore: newMonitorStore(),
+		uptimeStore:  uptime.NewStore(),
+	}
+}
+
+// ongoing indica se a queda mais recente do host continua em aberto
+func ongoing(t *testing.T, b *Bot, host string) bool {
+	t.Helper()
+	now := time.Now()
+	outages := b.uptimeStore.Outages("matriz", host, now.Add(-24*time.Hour), now.Add(time.Hour))
+	if len(outages) == 0 {
+		t.Fatalf("nenhuma queda de %s registrada", host)
+	}
+	return outages[len(outages)-1].Ongoing
+}
+
+func TestApplyClosesOutageOfDroppedHost(t *testing.T) {
+	b := uptimeBot(t)
+	m := newTestMonitor()
+	m.zabbix = &zabbix.Client{Name: "matriz"}
+	now := time.Now().Add(-time.Hour)
+
+	down := []monitor.Result{result("SRV01", monitor.StatusProblem)}
+	m.apply(down, now)
+	problems, recovered, _ := m.apply(down, now.Add(5*time.Minute))
+	m.recordUptime(b, problems, recovered, now.Add(5*time.Minute))
+	if !ongoing(t, b, "SRV01") {
+		t.Fatal("queda do SRV01 não ficou em aberto")
+	}
+
+	// O host entrou em manutenção e deixou de aparecer nos resultados
+	_, _, dropped := m.apply(nil, now.Add(10*time.Minute))
+	if len(dropped) != 1 || dropped[0].id != "SRV01" {
+		t.Fatalf("dropped = %+v, esperado o SRV01", dropped)
+	}
+	m.closeOutages(b, dropped, now.Add(10*time.Minute))
+	if ongoing(t, b, "SRV01") {
+		t.Error("queda do SRV01 continua em aberto depois que ele deixou de ser acompanhado")
+	}
+}
+
+func TestStopMonitorClosesOutages(t *testing.T) {
+	b := uptimeBot(t)
+	z := &zabbix.Client{Name: "matriz"}
+	since := time.Now().Add(-time.Hour)
+
+	m := downMonitor(since, "SRV01", "SRV02")
+	m.zabbix = z
+	other := downMonitor(since, "SRV02")
+	other.ID, other.zabbix = 2, z
+	b.Monitors[m.ID], b.Monitors[other.ID] = m, other
+
+	for _, host := range []string{"SRV01", "SRV02"} {
+		if err := b.uptimeStore.Record(z.Name, host, true, since); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b.stopMonitor(m)
+	if ongoing(t, b, "SRV01") {
+		t.Error("queda do SRV01 continua em aberto depois que o monitor parou")
+	}
+	if !ongoing(t, b, "SRV02") {
+		t.Error("queda do SRV02 encerrada, mas ele segue fora em outro monitor")
+	}
+}
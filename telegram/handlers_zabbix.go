@@ -6,42 +6,113 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"regexp"
-	"strconv"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func (b *Bot) handleStatusCheck(update tgbotapi.Update) {
+// collapseStatuses agrupa os hosts offline que dependem de um host offline. Devolve
+// os hosts agrupados, pelo ID, e a quantidade de dependentes de cada host do qual
+// dependem, pelo nome em minúsculas. Hosts sem dados não contam como offline.
+func collapseStatuses(statuses []monitor.HostStatus, deps monitor.Dependencies) (collapsed map[string]bool, dependents map[string]int) {
+	down := make(map[string]bool)
+	for _, s := range statuses {
+		if !s.Online && !s.Unknown {
+			down[strings.ToLower(s.Host)] = true
+		}
+	}
+	isDown := func(host string) bool { return down[strings.ToLower(host)] }
+
+	collapsed = make(map[string]bool)
+	dependents = make(map[string]int)
+	for _, s := range statuses {
+		if root, ok := deps.Root(s.Host, isDown); ok && !s.Online {
+			collapsed[s.Hostid] = true
+			dependents[strings.ToLower(root)]++
+		}
+	}
+	return collapsed, dependents
+}
+
+func (b *Bot) handleStatusCheck(update tgbotapi.Update, target zabbixTarget) {
 	// Envia mensagem inicial
 	processingMsg := tgbotapi.NewMessage(update.Message.Chat.ID, "⏳ Consultando status dos hosts no Zabbix...")
 	tempMsg, _ := b.API.Send(processingMsg)
 
-	hosts, err := monitor.CheckHostsStatus(b.Zabbix)
-	if err != nil {
-		errorMsg := fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	// Com /zbx_use todos, junta os hosts de todos os servidores: online primeiro, depois offline
+	targets := b.zabbixTargets(target)
+	var online, offline, failures []string
+	for _, z := range targets {
+		statuses, err := monitor.HostStatuses(ctx, z)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("⚠️ %s: %v", z.Name, err))
+			log.Println(err)
+			continue
+		}
+
+		// Hosts que dependem de um host offline aparecem só na contagem dele
+		deps, err := b.dependencies(ctx, z)
+		if err != nil {
+			log.Println(err)
+		}
+		collapsed, dependents := collapseStatuses(statuses, deps)
+
+		label := ""
+		if len(targets) > 1 {
+			label = " [" + z.Name + "]"
+		}
+		for _, s := range statuses {
+			if s.Online {
+				online = append(online, "✅ "+s.Host+label)
+				continue
+			}
+			if collapsed[s.Hostid] {
+				continue
+			}
+			h := "❌ " + s.Host
+			if n := dependents[strings.ToLower(s.Host)]; n > 0 {
+				h += siteDown(n)
+			}
+			// Hosts silenciados continuam na lista, só identificados
+			if _, muted := b.muteStore.active(update.Message.Chat.ID, s.Host); muted {
+				h += " 🔕"
+			}
+			offline = append(offline, h+label)
+		}
+	}
+
+	if len(failures) == len(targets) {
+		errorMsg := fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%s", strings.Join(failures, "\n"))
 		edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, errorMsg)
 		b.API.Send(edit)
-		log.Println(err)
 		return
 	}
 
 	msg := "🚥🚥🚥 Status dos Hosts 🚥🚥🚥\n\n"
-	for _, h := range hosts {
+	for _, h := range append(online, offline...) {
 		msg += h + "\n"
 	}
+	if len(failures) > 0 {
+		msg += "\n" + strings.Join(failures, "\n") + "\n"
+	}
 
 	edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, msg)
 	b.API.Send(edit)
 }
 
-func (b *Bot) handlePrinterCounter(update tgbotapi.Update) {
+func (b *Bot) handlePrinterCounter(update tgbotapi.Update, target zabbixTarget) {
 	// Envia mensagem inicial
 	processingMsg := tgbotapi.NewMessage(update.Message.Chat.ID, "⏳ Coletando contadores das impressoras...")
 	tempMsg, _ := b.API.Send(processingMsg)
 
-	printers, err := monitor.GetPrintersCounter(b.Zabbix)
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	printers, err := monitor.GetPrintersCounter(ctx, z)
 	if err != nil {
 		errorMsg := fmt.Sprintf("❌ Erro ao consultar Zabbix:\n%v", err)
 		edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, errorMsg)
@@ -94,12 +165,16 @@ func (b *Bot) handlePrinterCounter(update tgbotapi.Update) {
 	b.API.Send(finalMsg)
 }
 
-func (b *Bot) handleListIp(update tgbotapi.Update) {
+func (b *Bot) handleListIp(update tgbotapi.Update, target zabbixTarget) {
 	// Envia mensagem inicial
 	processingMsg := tgbotapi.NewMessage(update.Message.Chat.ID, "⏳ Consultando lista de IPs no Zabbix...")
 	tempMsg, _ := b.API.Send(processingMsg)
 
-	hostsList, err := b.Zabbix.ListIps()
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	hostsList, err := z.ListIps(ctx)
 	if err != nil {
 		errorMsg := fmt.Sprintf("❌ Erro ao listar Zabbix:\n%v", err)
 		edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, errorMsg)
@@ -116,17 +191,22 @@ func (b *Bot) handleListIp(update tgbotapi.Update) {
 		}
 		msg += "\n"
 	}
+	msg += inventoryNote(z)
 
 	edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, msg)
 	b.API.Send(edit)
 }
 
-func (b *Bot) handleProtheusStatus(update tgbotapi.Update) {
+func (b *Bot) handleProtheusStatus(update tgbotapi.Update, target zabbixTarget) {
 	// Envia mensagem inicial
 	processingMsg := tgbotapi.NewMessage(update.Message.Chat.ID, "⏳ Consultando status dos serviços Protheus...")
 	tempMsg, _ := b.API.Send(processingMsg)
 
-	services, err := b.Zabbix.GetProtheusServiceStatus()
+	z := target.client
+	ctx, cancel := b.zabbixContext()
+	defer cancel()
+
+	services, err := z.GetProtheusServiceStatus(ctx)
 	if err != nil {
 		errorMsg := fmt.Sprintf("❌ Erro ao pegar os status:\n%v", err)
 		edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, errorMsg)
@@ -136,53 +216,13 @@ func (b *Bot) handleProtheusStatus(update tgbotapi.Update) {
 
 	msg := "⚙️⚙️⚙️ Protheus Services Status ⚙️⚙️⚙️\n\n"
 	for _, service := range services {
-		name := ""
-		re := regexp.MustCompile(`"([^"]+)"`)
-		match := re.FindStringSubmatch(service.Name)
-
-		if len(match) > 1 {
-			name = match[1]
-		} else {
-			name = service.Name
-		}
-
-		if service.Lastvalue == "0" && service.Prevvalue == "0" {
-			msg += fmt.Sprintf("✅ %s\n", name)
+		if service.Running() {
+			msg += fmt.Sprintf("✅ %s\n", service.ServiceName())
 		} else {
-			msg += fmt.Sprintf("❌ %s\n", name)
+			msg += fmt.Sprintf("❌ %s\n", service.ServiceName())
 		}
 	}
 
 	edit := tgbotapi.NewEditMessageText(update.Message.Chat.ID, tempMsg.MessageID, msg)
 	b.API.Send(edit)
 }
-
-func (b *Bot) handleStatusMonitor(update tgbotapi.Update) {
-	chatID := update.Message.Chat.ID
-
-	// Exemplo de uso: /status_monitor 5   (5 minutos)
-	parts := strings.Fields(update.Message.Text)
-	if len(parts) < 2 {
-		msg := tgbotapi.NewMessage(chatID, "Uso: /status_monitor <minutos>\nExemplo: /status_monitor 5")
-		b.API.Send(msg)
-		return
-	}
-
-	minutes, err := strconv.Atoi(parts[1])
-	if err != nil || minutes <= 0 {
-		msg := tgbotapi.NewMessage(chatID, "Intervalo inválido. Informe um número inteiro de minutos.")
-		b.API.Send(msg)
-		return
-	}
-
-	if _, ok := b.Monitors[chatID]; ok {
-		b.API.Send(tgbotapi.NewMessage(chatID, "Já existe um monitor em execução para este chat."))
-		return
-	}
-
-	m := NewMonitor(chatID, minutes)
-	b.Monitors[chatID] = m
-	go m.run(b)
-
-	b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Monitor iniciado: checagem a cada %d minutos. Vou avisar somente quando houver hosts offline.", minutes)))
-}
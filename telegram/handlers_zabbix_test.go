@@ -0,0 +1,26 @@
+package bot
+
+import (
+	"LapaTelegramBot/monitor"
+	"reflect"
+	"testing"
+)
+
+func TestCollapseStatuses(t *testing.T) {
+	deps := monitor.Dependencies{"srv-filial-sp": "RT-FILIAL-SP", "srv-filial-rj": "RT-FILIAL-RJ"}
+	statuses := []monitor.HostStatus{
+		{Hostid: "1", Host: "RT-FILIAL-SP"},
+		{Hostid: "2", Host: "SRV-FILIAL-SP"},
+		// Sem dados do roteador: o servidor offline aparece sozinho
+		{Hostid: "3", Host: "RT-FILIAL-RJ", Unknown: true},
+		{Hostid: "4", Host: "SRV-FILIAL-RJ"},
+	}
+
+	collapsed, dependents := collapseStatuses(statuses, deps)
+	if want := map[string]bool{"2": true}; !reflect.DeepEqual(collapsed, want) {
+		t.Errorf("agrupados = %v, esperado %v", collapsed, want)
+	}
+	if want := map[string]int{"rt-filial-sp": 1}; !reflect.DeepEqual(dependents, want) {
+		t.Errorf("dependentes = %v, esperado %v", dependents, want)
+	}
+}
@@ -0,0 +1,153 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// severityIcons são os ícones de cada severidade do Zabbix, indexados pelo valor numérico
+var severityIcons = []string{"⚪", "🔵", "🟡", "🟠", "🔴", "🟣"}
+
+func severityIcon(severity int) string {
+	if severity < 0 || severity >= len(severityIcons) {
+		return "❔"
+	}
+	return severityIcons[severity]
+}
+
+// formatDuration formata durações de forma compacta, ex: "2d 3h", "1h 20min", "45min"
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dmin", hours, minutes)
+	default:
+		return fmt.Sprintf("%dmin", minutes)
+	}
+}
+
+// parseDuration aceita o formato de time.ParseDuration e também dias, ex: "1d", "2d12h"
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	var total time.Duration
+
+	if i := strings.Index(value, "d"); i > 0 {
+		days, err := strconv.Atoi(value[:i])
+		if err != nil {
+			return 0, fmt.Errorf("duração inválida: %s", value)
+		}
+		total = time.Duration(days) * 24 * time.Hour
+		value = value[i+1:]
+	}
+
+	if value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("duração inválida: %s", value)
+		}
+		total += d
+	}
+
+	if total <= 0 {
+		return 0, fmt.Errorf("duração inválida: %s", value)
+	}
+	return total, nil
+}
+
+// parseOptions separa argumentos no formato chave=valor dos demais argumentos
+func parseOptions(args []string) (map[string]string, []string) {
+	options := make(map[string]string)
+	var rest []string
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok && key != "" {
+			options[strings.ToLower(key)] = value
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return options, rest
+}
+
+// maxMessageLength é o limite de uma mensagem do Telegram (4096), com folga.
+// Medido em bytes, que nunca são menos que as unidades UTF-16 contadas pelo Telegram.
+const maxMessageLength = 4000
+
+// splitMessage quebra o texto em partes que cabem em uma mensagem, preferindo quebras de linha
+func splitMessage(text string) []string {
+	var parts []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if current.Len()+len(line) > maxMessageLength {
+			flush()
+		}
+		// Linha maior que o limite: corta sem partir caracteres multibyte
+		for len(line) > maxMessageLength {
+			cut := maxMessageLength
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			parts = append(parts, line[:cut])
+			line = line[cut:]
+		}
+		current.WriteString(line)
+	}
+	flush()
+	return parts
+}
+
+// maxCaptionLength é o limite da legenda de uma foto no Telegram, em unidades UTF-16
+const maxCaptionLength = 1024
+
+// truncateCaption corta a legenda no limite do Telegram, terminando em "…"
+func truncateCaption(text string) string {
+	if utf16Len(text) <= maxCaptionLength {
+		return text
+	}
+	units := 0
+	for i, r := range text {
+		// Reserva uma unidade para o "…"
+		if units+runeLen16(r) > maxCaptionLength-1 {
+			return text[:i] + "…"
+		}
+		units += runeLen16(r)
+	}
+	return text
+}
+
+// utf16Len conta o texto em unidades UTF-16, como o Telegram mede os limites
+func utf16Len(text string) int {
+	n := 0
+	for _, r := range text {
+		n += runeLen16(r)
+	}
+	return n
+}
+
+func runeLen16(r rune) int {
+	if n := utf16.RuneLen(r); n > 0 {
+		return n
+	}
+	// Rune inválida vira U+FFFD, uma unidade
+	return 1
+}
@@ -0,0 +1,25 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateCaption(t *testing.T) {
+	short := "• CPU\n  último 12 %"
+	if got := truncateCaption(short); got != short {
+		t.Errorf("truncateCaption(%q) = %q, esperado sem corte", short, got)
+	}
+
+	long := strings.Repeat("média ", 300)
+	got := truncateCaption(long)
+	if n := utf16Len(got); n != maxCaptionLength || !strings.HasSuffix(got, "…") {
+		t.Errorf("legenda cortada com %d unidades, esperado %d terminando em …", n, maxCaptionLength)
+	}
+
+	// Emojis fora do BMP contam duas unidades e não podem ser partidos
+	emojis := strings.Repeat("📈", 600)
+	if n := utf16Len(truncateCaption(emojis)); n > maxCaptionLength {
+		t.Errorf("legenda com emojis ficou com %d unidades, limite %d", n, maxCaptionLength)
+	}
+}
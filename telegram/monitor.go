@@ -1,100 +1,589 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"LapaTelegramBot/config"
 	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/zabbix"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// monitorFailThreshold é o padrão de MONITOR_FAIL_THRESHOLD: checagens seguidas com
+// problema antes de avisar, para evitar alertas de hosts oscilando
+const monitorFailThreshold = 2
+
+// Monitor executa uma checagem periodicamente e avisa o chat quando os resultados
+// mudam de estado. Um chat pode ter vários monitores, identificados pelo ID.
 type Monitor struct {
-	ChatID          int64
+	ID     int64
+	ChatID int64
+	check  monitor.Check
+	zabbix *zabbix.Client // servidor monitorado, escolhido ao iniciar o monitor
+
+	stopCh         chan struct{}
+	updateInterval chan int
+	ctx            context.Context
+	cancel         context.CancelFunc
+
+	// failThreshold é a quantidade de checagens seguidas com problema antes do aviso
+	failThreshold int
+
+	// mu protege os campos abaixo, lidos pelo /monitor_list
+	mu              sync.Mutex
 	IntervalMinutes int
-	stopCh          chan struct{}
-	updateInterval  chan int
-	waitingInterval bool
-	lastMsgID       int
+	// states guarda o estado de cada resultado entre as checagens, pelo ID do resultado
+	states map[string]*resultState
+	// summaryMsgID é a mensagem de resumo, editada a cada checagem
+	summaryMsgID int
+	lastCheck    time.Time
+	lastErr      error
+	unknown      int
+	// deps são as dependências entre hosts usadas para agrupar os avisos de um site fora
+	deps monitor.Dependencies
+}
+
+// resultState é o estado de um resultado acompanhado pelo monitor
+type resultState struct {
+	Name   string `json:"name"`
+	Host   string `json:"host,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	// Failures é a quantidade de checagens seguidas com problema
+	Failures int `json:"failures"`
+	// Since é o horário da primeira checagem com problema
+	Since time.Time `json:"since"`
+	// Down indica que o aviso de problema já foi enviado
+	Down bool `json:"down"`
+	// Escalated é a quantidade de níveis de escalonamento já disparados no incidente
+	Escalated int `json:"escalated,omitempty"`
+	// AckedBy é quem reconheceu o incidente, encerrando o escalonamento
+	AckedBy string `json:"acked_by,omitempty"`
+	// Parent é o host fora do ar do qual o host depende; o resultado não é avisado
+	// sozinho, mas agrupado no aviso do Parent
+	Parent string `json:"parent,omitempty"`
+	// Muted indica que o host estava silenciado no chat durante o problema
+	Muted bool `json:"muted,omitempty"`
+	// EscalateFrom é o fim do silêncio ou a abertura da janela de avisos, de onde o
+	// escalonamento volta a contar
+	EscalateFrom time.Time `json:"escalate_from,omitempty"`
+}
+
+// escalationStart é o horário a partir do qual o escalonamento conta
+func (s resultState) escalationStart() time.Time {
+	if !s.EscalateFrom.IsZero() {
+		return s.EscalateFrom
+	}
+	return s.Since
 }
 
-func NewMonitor(chatID int64, minutes int) *Monitor {
-	return &Monitor{ChatID: chatID, IntervalMinutes: minutes, stopCh: make(chan struct{}, 1), updateInterval: make(chan int, 1)}
+func NewMonitor(id, chatID int64, minutes int, z *zabbix.Client, check monitor.Check) *Monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	threshold := config.GetInt("MONITOR_FAIL_THRESHOLD", monitorFailThreshold)
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Monitor{
+		ID:              id,
+		ChatID:          chatID,
+		IntervalMinutes: minutes,
+		check:           check,
+		zabbix:          z,
+		stopCh:          make(chan struct{}, 1),
+		updateInterval:  make(chan int, 1),
+		ctx:             ctx,
+		cancel:          cancel,
+		failThreshold:   threshold,
+		states:          make(map[string]*resultState),
+	}
+}
+
+// title identifica o monitor nas mensagens, ex: "Monitor #2 · Ping dos hosts [matriz]"
+func (m *Monitor) title(b *Bot) string {
+	return fmt.Sprintf("Monitor #%d · %s%s", m.ID, m.check.Describe(), b.serverLabel(m.zabbix))
+}
+
+func (m *Monitor) interval() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return time.Duration(m.IntervalMinutes) * time.Minute
 }
 
 func (m *Monitor) run(b *Bot) {
-	exclude := []string{"Applications", "Impressoras"}
+	defer m.cancel()
+
+	// Execute a primeira checagem imediatamente
+	m.runCheck(b)
 
-	// Função que realiza a checagem e envia notificação se necessário
-	doCheck := func() {
-		hosts, err := monitor.CheckHostsStatusExcludingGroups(b.Zabbix, exclude)
-		if err != nil {
-			log.Printf("Erro ao checar hosts no monitor: %v", err)
+	ticker := time.NewTicker(m.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			log.Printf("Monitor %d stopped for chat %d", m.ID, m.ChatID)
 			return
+		case newInterval := <-m.updateInterval:
+			if newInterval <= 0 {
+				continue
+			}
+			m.mu.Lock()
+			m.IntervalMinutes = newInterval
+			m.mu.Unlock()
+			ticker.Reset(m.interval())
+			log.Printf("Monitor %d interval updated to %d minutes for chat %d", m.ID, newInterval, m.ChatID)
+			m.updateSummary(b)
+			b.monitorStore.put(m)
+		case <-ticker.C:
+			m.runCheck(b)
 		}
+	}
+}
+
+// stop interrompe o monitor, inclusive uma checagem em andamento
+func (m *Monitor) stop() {
+	// sinaliza parada sem fechar o channel diretamente
+	select {
+	case m.stopCh <- struct{}{}:
+	default:
+	}
+	m.cancel()
+}
 
-		var offline []string
-		for _, h := range hosts {
-			if strings.HasPrefix(h, "❌") {
-				offline = append(offline, h)
+// runCheck executa a checagem, avisa as mudanças de estado e atualiza o resumo
+func (m *Monitor) runCheck(b *Bot) {
+	// A checagem não pode ultrapassar o intervalo do próprio monitor
+	ctx, cancel := context.WithTimeout(m.ctx, m.interval())
+	defer cancel()
+
+	results, err := m.check.Run(ctx, m.zabbix)
+	if m.ctx.Err() != nil {
+		return
+	}
+
+	// As dependências só valem para o ping, que acompanha o estado dos próprios hosts.
+	// Se a consulta das tags falhar, mantém as dependências da checagem anterior.
+	// Só esta goroutine altera m.deps, que pode ser lido aqui sem a trava.
+	var deps monitor.Dependencies
+	if _, ok := m.check.(monitor.PingCheck); ok && err == nil {
+		var depsErr error
+		if deps, depsErr = b.dependencies(ctx, m.zabbix); depsErr != nil {
+			log.Printf("Erro ao buscar as dependências do monitor %d: %v", m.ID, depsErr)
+			if m.deps != nil {
+				deps = m.deps
 			}
 		}
+	}
 
-		if len(offline) == 0 {
-			// Nenhuma ação se todos online
-			return
+	now := time.Now()
+	m.mu.Lock()
+	m.lastCheck = now
+	m.lastErr = err
+	var problems, recovered, dropped []transition
+	var fired []escalation
+	if err == nil {
+		m.deps = deps
+		problems, recovered, dropped = m.apply(results, now)
+		problems = m.collapse(problems)
+		problems = m.unmuted(b, problems, now)
+		fired = m.escalate(b, now)
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Erro na checagem do monitor %d: %v", m.ID, err)
+	}
+	m.recordUptime(b, problems, recovered, now)
+	m.closeOutages(b, dropped, now)
+	m.notify(b, problems, recovered)
+	if failed := m.sendEscalations(b, fired); len(failed) > 0 {
+		m.mu.Lock()
+		m.retryEscalations(failed)
+		m.mu.Unlock()
+	}
+	m.notifyEscalatedRecovery(b, recovered)
+	m.updateSummary(b)
+	b.monitorStore.put(m)
+}
+
+// transition é a mudança de estado de um resultado em uma checagem
+type transition struct {
+	id    string
+	state resultState // cópia do estado no momento da mudança
+	// downFor é o tempo com problema, nas recuperações
+	downFor time.Duration
+}
+
+// apply atualiza o estado dos resultados e devolve os novos problemas, as
+// recuperações e os resultados com problema que deixaram de ser acompanhados.
+// Deve ser chamado com m.mu travado.
+func (m *Monitor) apply(results []monitor.Result, now time.Time) (problems, recovered, dropped []transition) {
+	seen := make(map[string]bool, len(results))
+	unknownHosts := make(map[string]bool)
+	m.unknown = 0
+
+	for _, r := range results {
+		seen[r.ID] = true
+		if r.Status == monitor.StatusUnknown && r.Host != "" {
+			unknownHosts[strings.ToLower(r.Host)] = true
+		}
+		state, ok := m.states[r.ID]
+		if !ok {
+			state = &resultState{}
+			m.states[r.ID] = state
 		}
+		state.Name, state.Host = r.Name, r.Host
 
-		// Envia mensagem com botões; antes apaga a última mensagem enviada pelo monitor
-		if m.lastMsgID != 0 {
-			del := tgbotapi.NewDeleteMessage(m.ChatID, m.lastMsgID)
-			b.API.Send(del)
-			m.lastMsgID = 0
+		switch r.Status {
+		case monitor.StatusUnknown:
+			// Sem dados nesta checagem: mantém o estado anterior. Só conta como sem
+			// dados quem estava OK; os demais seguem contados como fora ou em observação.
+			if !state.Down && state.Failures == 0 {
+				m.unknown++
+			}
+		case monitor.StatusOK:
+			if state.Down {
+				recovered = append(recovered, transition{id: r.ID, state: *state, downFor: now.Sub(state.Since)})
+			}
+			*state = resultState{Name: r.Name, Host: r.Host, Detail: r.Detail}
+		default:
+			state.Detail = r.Detail
+			if state.Failures == 0 {
+				state.Since = now
+			}
+			state.Failures++
+			if !state.Down && state.Failures >= m.failThreshold {
+				state.Down = true
+				problems = append(problems, transition{id: r.ID, state: *state})
+			}
 		}
+	}
 
-		msgText := "❗ Hosts offline encontrados:\n\n"
-		for _, o := range offline {
-			msgText += o + "\n"
+	// Resultados que deixaram de aparecer (hosts desativados, em manutenção,
+	// itens removidos) deixam de ser acompanhados. Os de um host sem dados nesta
+	// checagem, como uma impressora cuja consulta dos itens falhou, são mantidos.
+	for id, state := range m.states {
+		if !seen[id] && !unknownHosts[strings.ToLower(state.Host)] {
+			if state.Down {
+				dropped = append(dropped, transition{id: id, state: *state})
+			}
+			delete(m.states, id)
 		}
+	}
+	return problems, recovered, dropped
+}
 
-		kb := tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("⏱️ Aumentar intervalo", fmt.Sprintf("monitor:increase:%d", m.ChatID)),
-				tgbotapi.NewInlineKeyboardButtonData("🛑 Parar aviso", fmt.Sprintf("monitor:stop:%d", m.ChatID)),
-			),
-		)
+// unmuted marca os resultados com problema cujo host está silenciado no chat e
+// acrescenta aos novos problemas os que seguem com problema depois que o silêncio
+// acabou, para serem avisados de novo. O escalonamento deles volta a contar do fim
+// do silêncio. Deve ser chamado com m.mu travado.
+func (m *Monitor) unmuted(b *Bot, problems []transition, now time.Time) []transition {
+	announced := make(map[string]bool, len(problems))
+	for _, t := range problems {
+		announced[t.id] = true
+	}
 
-		msg := tgbotapi.NewMessage(m.ChatID, msgText)
-		msg.ReplyMarkup = kb
-		sent, err := b.API.Send(msg)
-		if err == nil {
-			m.lastMsgID = sent.MessageID
+	for id, state := range m.states {
+		if !state.Down || state.Parent != "" {
+			continue
+		}
+		if _, muted := b.muteStore.active(m.ChatID, state.Host); muted {
+			state.Muted = true
+			continue
+		}
+		if state.Muted {
+			state.Muted = false
+			state.EscalateFrom = now
+			if !announced[id] {
+				problems = append(problems, transition{id: id, state: *state})
+			}
 		}
 	}
+	return problems
+}
 
-	// Execute a primeira checagem imediatamente
-	doCheck()
+// monitorMuteButtons limita as linhas de botões de um aviso; os demais hosts podem
+// ser silenciados com /mute
+const monitorMuteButtons = 8
 
-	ticker := time.NewTicker(time.Duration(m.IntervalMinutes) * time.Minute)
-	defer ticker.Stop()
+// notify envia os avisos de novos problemas, com botões para silenciar cada host e,
+// com escalonamento configurado, para reconhecer cada incidente, e de recuperações.
+// Hosts silenciados no chat não geram avisos; o silêncio "até se recuperar" termina
+// na recuperação, que é avisada. Hosts que dependem de um host fora do ar são
+// agrupados no aviso dele. Fora da janela de avisos do chat, os avisos vão
+// para o resumo enviado quando a janela abre.
+func (m *Monitor) notify(b *Bot, problems, recovered []transition) {
+	var lines, held []string
+	var rows [][]tgbotapi.InlineKeyboardButton
+	buttons := make(map[string]bool)
+	down, _ := dependents(problems)
+	for _, t := range problems {
+		if t.state.Parent != "" {
+			continue
+		}
+		if mute, ok := b.muteStore.active(m.ChatID, t.state.Host); ok {
+			log.Printf("Aviso do monitor %d para %s silenciado %s", m.ID, t.state.Host, mute.describe())
+			continue
+		}
+		line := fmt.Sprintf("❌ %s (desde %s)", t.state.label(), t.state.Since.Format("02/01 15:04"))
+		if n := down[strings.ToLower(t.state.Host)]; n > 0 {
+			line += siteDown(n)
+		}
+		if b.holdAlert(m.ChatID, m.zabbix, t.state.Host, noSeverity) {
+			held = append(held, line)
+			continue
+		}
+		lines = append(lines, line)
+		if len(rows) >= monitorMuteButtons {
+			continue
+		}
 
-	for {
-		select {
-		case <-m.stopCh:
-			log.Printf("Monitor stopped for chat %d", m.ChatID)
-			return
-		case newInterval := <-m.updateInterval:
-			if newInterval <= 0 {
+		var row []tgbotapi.InlineKeyboardButton
+		if len(b.escalation) > 0 {
+			row = append(row, m.ackButton(t.id, "✅ "+t.state.Name))
+		}
+		host := t.state.Host
+		if host != "" && !buttons[strings.ToLower(host)] {
+			buttons[strings.ToLower(host)] = true
+			data := fmt.Sprintf("mute:%d:%s:", m.ID, t.id)
+			label := "🔕 " + host + " 1h"
+			if len(row) > 0 {
+				label = "🔕 1h"
+			}
+			row = append(row,
+				tgbotapi.NewInlineKeyboardButtonData(label, data+"1h"),
+				tgbotapi.NewInlineKeyboardButtonData("4h", data+"4h"),
+				tgbotapi.NewInlineKeyboardButtonData("Até voltar", data+muteUntilRecovery),
+			)
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	if len(lines) > 0 {
+		msg := tgbotapi.NewMessage(m.ChatID, fmt.Sprintf("🔴 %s\n\n%s", m.title(b), strings.Join(lines, "\n")))
+		if len(rows) > 0 {
+			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+		}
+		b.API.Send(msg)
+	}
+	if len(held) > 0 {
+		b.quietStore.hold(m.ChatID, fmt.Sprintf("🔴 %s\n%s", m.title(b), strings.Join(held, "\n")))
+	}
+
+	lines, held = nil, nil
+	back, parents := dependents(recovered)
+	for _, t := range recovered {
+		if t.state.Parent != "" {
+			continue
+		}
+		line := fmt.Sprintf("✅ %s voltou após %s", t.state.Name, formatDuration(t.downFor))
+		if n := back[strings.ToLower(t.state.Host)]; n > 0 {
+			line += fmt.Sprintf(" (+%d dependente(s))", n)
+			delete(back, strings.ToLower(t.state.Host))
+		}
+		if mute, ok := b.muteStore.active(m.ChatID, t.state.Host); ok {
+			if !mute.untilRecovery() {
 				continue
 			}
-			m.IntervalMinutes = newInterval
-			ticker.Stop()
-			ticker = time.NewTicker(time.Duration(m.IntervalMinutes) * time.Minute)
-			log.Printf("Monitor interval updated to %d minutes for chat %d", m.IntervalMinutes, m.ChatID)
-		case <-ticker.C:
-			doCheck()
+			b.muteStore.remove(m.ChatID, t.state.Host)
+			line += " (silêncio encerrado)"
 		}
+		if b.holdAlert(m.ChatID, m.zabbix, t.state.Host, noSeverity) {
+			held = append(held, line)
+			continue
+		}
+		lines = append(lines, line)
+	}
+	// Dependentes que voltaram depois do host do qual dependem
+	for _, key := range sortedKeys(back) {
+		line := fmt.Sprintf("✅ %d dependente(s) de %s voltaram", back[key], parents[key])
+		if b.holdAlert(m.ChatID, m.zabbix, parents[key], noSeverity) {
+			held = append(held, line)
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) > 0 {
+		b.API.Send(tgbotapi.NewMessage(m.ChatID, fmt.Sprintf("🟢 %s\n\n%s", m.title(b), strings.Join(lines, "\n"))))
+	}
+	if len(held) > 0 {
+		b.quietStore.hold(m.ChatID, fmt.Sprintf("🟢 %s\n%s", m.title(b), strings.Join(held, "\n")))
+	}
+}
+
+// label devolve o nome do resultado com o detalhe, ex: "IMP-RH: Toner preto, 8%"
+func (s *resultState) label() string {
+	if s.Detail == "" {
+		return s.Name
+	}
+	return s.Name + ", " + s.Detail
+}
+
+// monitorCounts resume os estados de um monitor
+type monitorCounts struct {
+	ok, down, pending, unknown int
+}
+
+// counts conta os resultados por estado. Deve ser chamado com m.mu travado.
+func (m *Monitor) counts() monitorCounts {
+	c := monitorCounts{unknown: m.unknown}
+	for _, state := range m.states {
+		switch {
+		case state.Down:
+			c.down++
+		case state.Failures > 0:
+			c.pending++
+		}
+	}
+	c.ok = len(m.states) - c.down - c.pending - c.unknown
+	return c
+}
+
+// summaryText monta o resumo com as contagens e os resultados com problema.
+// Deve ser chamado com m.mu travado.
+func (m *Monitor) summaryText(b *Bot) string {
+	var down, pending []*resultState
+	for _, state := range m.states {
+		switch {
+		case state.Down && state.Parent != "":
+			// Listado junto do host do qual depende
+		case state.Down:
+			down = append(down, state)
+		case state.Failures > 0:
+			pending = append(pending, state)
+		}
+	}
+	sort.Slice(down, func(i, j int) bool { return down[i].Since.Before(down[j].Since) })
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Name < pending[j].Name })
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📊 %s\n", m.title(b))
+	fmt.Fprintf(&sb, "Checagem a cada %d min, última às %s\n", m.IntervalMinutes, m.lastCheck.Format("15:04"))
+	if m.lastErr != nil {
+		fmt.Fprintf(&sb, "\n⚠️ Falha na última checagem: %v\n", m.lastErr)
+	}
+
+	c := m.counts()
+	fmt.Fprintf(&sb, "\n✅ OK: %d\n❌ Com problema: %d\n", c.ok, c.down)
+	if c.pending > 0 {
+		fmt.Fprintf(&sb, "⏳ Em observação: %d\n", c.pending)
+	}
+	if c.unknown > 0 {
+		fmt.Fprintf(&sb, "❔ Sem dados: %d\n", c.unknown)
+	}
+
+	var lines []string
+	dependents := m.dependentsDown()
+	for _, state := range down {
+		line := fmt.Sprintf("❌ %s, há %s", state.label(), formatDuration(m.lastCheck.Sub(state.Since)))
+		if n := dependents[strings.ToLower(state.Host)]; n > 0 {
+			line += siteDown(n)
+		}
+		if _, muted := b.muteStore.active(m.ChatID, state.Host); muted {
+			line += " 🔕"
+		}
+		if state.AckedBy != "" {
+			line += " (reconhecido por " + state.AckedBy + ")"
+		}
+		lines = append(lines, line)
+	}
+	for _, state := range pending {
+		lines = append(lines, fmt.Sprintf("⏳ %s, falha %d de %d", state.label(), state.Failures, m.failThreshold))
+	}
+	if len(lines) > 0 {
+		sb.WriteString("\n")
+	}
+	for i, line := range lines {
+		// O resumo é uma única mensagem: a lista é cortada antes do limite do Telegram
+		if sb.Len()+len(line) > maxMessageLength-100 {
+			fmt.Fprintf(&sb, "... e mais %d\n", len(lines)-i)
+			break
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// updateSummary edita a mensagem de resumo; se ela não existir mais, envia uma nova
+func (m *Monitor) updateSummary(b *Bot) {
+	m.mu.Lock()
+	text := m.summaryText(b)
+	msgID := m.summaryMsgID
+	m.mu.Unlock()
+
+	kb := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⏱️ Alterar intervalo", fmt.Sprintf("monitor:interval:%d", m.ID)),
+			tgbotapi.NewInlineKeyboardButtonData("🛑 Parar monitor", fmt.Sprintf("monitor:stop:%d", m.ID)),
+		),
+	)
+
+	if msgID != 0 {
+		edit := tgbotapi.NewEditMessageTextAndMarkup(m.ChatID, msgID, text, kb)
+		_, err := b.API.Send(edit)
+		if err == nil || strings.Contains(err.Error(), "message is not modified") {
+			return
+		}
+		log.Printf("Erro ao editar resumo do monitor %d, enviando novo: %v", m.ID, err)
+	}
+
+	msg := tgbotapi.NewMessage(m.ChatID, text)
+	msg.ReplyMarkup = kb
+	sent, err := b.API.Send(msg)
+	if err == nil {
+		m.mu.Lock()
+		m.summaryMsgID = sent.MessageID
+		m.mu.Unlock()
+	}
+}
+
+// handleMonitorCallback trata os botões do resumo dos monitores.
+// Formato: monitor:<stop|interval>:<ID do monitor>
+func (b *Bot) handleMonitorCallback(query *tgbotapi.CallbackQuery, args []string) {
+	chatID := query.Message.Chat.ID
+
+	var m *Monitor
+	if len(args) == 2 {
+		if id, err := strconv.ParseInt(args[1], 10, 64); err == nil {
+			m = b.chatMonitor(chatID, id)
+		}
+	}
+	if m == nil {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Monitor não encontrado."))
+		b.API.Send(tgbotapi.NewMessage(chatID, "Monitor não encontrado. Veja os monitores ativos com /monitor_list."))
+		return
+	}
+
+	switch args[0] {
+	case "stop":
+		b.stopMonitor(m)
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Monitor parado."))
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Monitor #%d parado.", m.ID)))
+	case "interval":
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Informe o novo intervalo."))
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Envie o novo intervalo do monitor #%d em minutos como mensagem nesta conversa.", m.ID)))
+		b.waitForInput(chatID, func(msg *tgbotapi.Message) {
+			minutes, err := strconv.Atoi(strings.TrimSpace(msg.Text))
+			if err != nil || minutes <= 0 {
+				b.API.Send(tgbotapi.NewMessage(chatID, "Intervalo inválido. Informe um número inteiro de minutos."))
+				return
+			}
+			// envia novo intervalo para o monitor
+			select {
+			case m.updateInterval <- minutes:
+			default:
+			}
+			b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("Intervalo do monitor #%d atualizado para %d minutos.", m.ID, minutes)))
+		})
+	default:
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
 	}
 }
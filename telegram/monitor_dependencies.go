@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// collapse agrupa os resultados com problema cujo host depende de outro host fora do
+// ar e devolve os novos problemas com o estado atualizado. Um resultado agrupado que
+// segue com problema depois que o host do qual depende voltou passa a ser avisado
+// sozinho. Deve ser chamado com m.mu travado.
+func (m *Monitor) collapse(problems []transition) []transition {
+	down := make(map[string]bool)
+	for _, state := range m.states {
+		if state.Down && state.Host != "" {
+			down[strings.ToLower(state.Host)] = true
+		}
+	}
+	isDown := func(host string) bool { return down[strings.ToLower(host)] }
+
+	announced := make(map[string]bool, len(problems))
+	for _, t := range problems {
+		announced[t.id] = true
+	}
+
+	for id, state := range m.states {
+		if !state.Down || state.Host == "" {
+			continue
+		}
+		root, ok := m.deps.Root(state.Host, isDown)
+		switch {
+		case ok:
+			state.Parent = root
+		case state.Parent != "":
+			state.Parent = ""
+			if !announced[id] {
+				problems = append(problems, transition{id: id})
+			}
+		}
+	}
+
+	for i := range problems {
+		problems[i].state = *m.states[problems[i].id]
+	}
+	return problems
+}
+
+// dependents conta os resultados agrupados em cada host do qual dependem, pelo nome
+// do host em minúsculas, e guarda o nome original de cada um
+func dependents(transitions []transition) (counts map[string]int, names map[string]string) {
+	counts = make(map[string]int)
+	names = make(map[string]string)
+	for _, t := range transitions {
+		if t.state.Parent == "" {
+			continue
+		}
+		key := strings.ToLower(t.state.Parent)
+		counts[key]++
+		names[key] = t.state.Parent
+	}
+	return counts, names
+}
+
+// dependentsDown conta os resultados com problema agrupados em cada host.
+// Deve ser chamado com m.mu travado.
+func (m *Monitor) dependentsDown() map[string]int {
+	counts := make(map[string]int)
+	for _, state := range m.states {
+		if state.Down && state.Parent != "" {
+			counts[strings.ToLower(state.Parent)]++
+		}
+	}
+	return counts
+}
+
+// siteDown descreve os hosts agrupados em um host fora do ar
+func siteDown(count int) string {
+	return fmt.Sprintf(" · site inacessível, +%d dependente(s) fora", count)
+}
+
+// sortedKeys devolve as chaves do mapa em ordem
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
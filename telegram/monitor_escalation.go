@@ -0,0 +1,325 @@
+package bot
+
+import (
+	"LapaTelegramBot/config"
+	"LapaTelegramBot/mailer"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Tipos de nível de escalonamento
+const (
+	escalateChat  = "chat"
+	escalateEmail = "email"
+)
+
+// escalationLevel é um nível de escalonamento: depois de After com o problema ativo
+// e sem reconhecimento, avisa os chats ou emails de Targets
+type escalationLevel struct {
+	After   time.Duration
+	Kind    string
+	Targets []string
+}
+
+// describe descreve o nível, ex: "nível 2 (60min, email)"
+func (l escalationLevel) describe(n int) string {
+	return fmt.Sprintf("nível %d (%s, %s)", n, formatDuration(l.After), l.Kind)
+}
+
+// loadEscalation lê MONITOR_ESCALATION: níveis separados por ";", cada um no formato
+// <tempo>:<chat|email>:<destinos separados por vírgula>, ex:
+// "15m:chat:-100123456;1h:email:gerente@empresa.com". Níveis inválidos são ignorados.
+func loadEscalation() []escalationLevel {
+	var levels []escalationLevel
+	for _, entry := range strings.Split(config.Get("MONITOR_ESCALATION", ""), ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fields := strings.SplitN(entry, ":", 3)
+		if len(fields) != 3 {
+			log.Printf("⚠️  Nível inválido em MONITOR_ESCALATION: %s", entry)
+			continue
+		}
+		after, err := parseDuration(fields[0])
+		kind := strings.ToLower(strings.TrimSpace(fields[1]))
+		targets := config.SplitList(fields[2])
+		if err != nil || (kind != escalateChat && kind != escalateEmail) || len(targets) == 0 {
+			log.Printf("⚠️  Nível inválido em MONITOR_ESCALATION: %s", entry)
+			continue
+		}
+		if kind == escalateChat && !validChatIDs(targets) {
+			log.Printf("⚠️  Chat inválido em MONITOR_ESCALATION: %s", entry)
+			continue
+		}
+
+		levels = append(levels, escalationLevel{After: after, Kind: kind, Targets: targets})
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool { return levels[i].After < levels[j].After })
+	return levels
+}
+
+func validChatIDs(targets []string) bool {
+	for _, t := range targets {
+		if _, err := strconv.ParseInt(t, 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// escalationChat indica se o chat recebe algum nível de escalonamento
+func (b *Bot) escalationChat(chatID int64) bool {
+	target := strconv.FormatInt(chatID, 10)
+	for _, level := range b.escalation {
+		if level.Kind != escalateChat {
+			continue
+		}
+		for _, t := range level.Targets {
+			if t == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// escalation é o disparo de um nível para um resultado com problema
+type escalation struct {
+	level int // índice em b.escalation
+	transition
+	// dependents são os hosts fora agrupados no resultado
+	dependents int
+}
+
+// escalate marca os níveis que venceram para os resultados com problema, sem
+// reconhecimento, sem silêncio e não agrupados em outro host. Cada nível dispara uma única vez por incidente.
+// Depois de um silêncio ou com a janela de avisos fechada, os níveis contam do fim
+// deles. Deve ser chamado com m.mu travado.
+func (m *Monitor) escalate(b *Bot, now time.Time) []escalation {
+	var fired []escalation
+	dependents := m.dependentsDown()
+	for id, state := range m.states {
+		// Hosts agrupados em um host fora do ar escalonam junto com ele
+		if !state.Down || state.AckedBy != "" || state.Parent != "" {
+			continue
+		}
+		if _, muted := b.muteStore.active(m.ChatID, state.Host); muted {
+			continue
+		}
+		if b.holdAlert(m.ChatID, m.zabbix, state.Host, noSeverity) {
+			state.EscalateFrom = now
+			continue
+		}
+		for state.Escalated < len(b.escalation) && now.Sub(state.escalationStart()) >= b.escalation[state.Escalated].After {
+			fired = append(fired, escalation{
+				level:      state.Escalated,
+				transition: transition{id: id, state: *state, downFor: now.Sub(state.Since)},
+				dependents: dependents[strings.ToLower(state.Host)],
+			})
+			state.Escalated++
+		}
+	}
+	sort.Slice(fired, func(i, j int) bool {
+		if fired[i].level != fired[j].level {
+			return fired[i].level < fired[j].level
+		}
+		return fired[i].state.Name < fired[j].state.Name
+	})
+	return fired
+}
+
+// sendEscalations envia um aviso por nível com os resultados que o atingiram. Os
+// avisos nos chats têm botões para reconhecer cada incidente. Devolve os disparos
+// que não chegaram a nenhum destino, para serem repetidos na próxima checagem.
+func (m *Monitor) sendEscalations(b *Bot, fired []escalation) (failed []escalation) {
+	for start := 0; start < len(fired); {
+		end := start
+		for end < len(fired) && fired[end].level == fired[start].level {
+			end++
+		}
+		level := b.escalation[fired[start].level]
+		group := fired[start:end]
+		start = end
+
+		var lines []string
+		for _, e := range group {
+			line := fmt.Sprintf("❌ %s, há %s", e.state.label(), formatDuration(e.downFor))
+			if n := e.dependents; n > 0 {
+				line += siteDown(n)
+			}
+			lines = append(lines, line)
+		}
+		header := fmt.Sprintf("🚨 Escalonamento %s\n%s", level.describe(group[0].level+1), m.title(b))
+
+		var err error
+		delivered := false
+		switch level.Kind {
+		case escalateChat:
+			delivered, err = m.escalateToChats(b, level.Targets, header, lines, group)
+		case escalateEmail:
+			err = b.Mailer.SendEmail(mailer.EmailMessage{
+				From:    mailFrom,
+				To:      level.Targets,
+				Subject: fmt.Sprintf("[Escalonamento] %s: %d com problema", m.check.Describe(), len(group)),
+				Body:    header + "\n\n" + strings.Join(lines, "\n") + "\n\nReconheça o incidente pelo Telegram para encerrar o escalonamento.",
+			})
+			delivered = err == nil
+		}
+
+		if !delivered {
+			failed = append(failed, group...)
+		}
+		if err != nil {
+			log.Printf("Erro no escalonamento do monitor %d: %v", m.ID, err)
+			b.API.Send(tgbotapi.NewMessage(m.ChatID, fmt.Sprintf("⚠️ Falha no escalonamento %s do monitor #%d:\n%v", level.describe(group[0].level+1), m.ID, err)))
+			continue
+		}
+		b.API.Send(tgbotapi.NewMessage(m.ChatID, fmt.Sprintf("📣 Monitor #%d escalonado, %s: %s", m.ID, level.describe(group[0].level+1), strings.Join(level.Targets, ", "))))
+	}
+	return failed
+}
+
+// retryEscalations volta o nível dos incidentes cujo escalonamento não foi entregue,
+// para que ele dispare de novo na próxima checagem. Deve ser chamado com m.mu travado.
+func (m *Monitor) retryEscalations(failed []escalation) {
+	for _, e := range failed {
+		state, ok := m.states[e.id]
+		// O incidente pode ter terminado enquanto o aviso era enviado
+		if !ok || !state.Down || !state.Since.Equal(e.state.Since) {
+			continue
+		}
+		state.Escalated = min(state.Escalated, e.level)
+	}
+}
+
+// escalateToChats envia o escalonamento aos chats; delivered indica que ao menos um
+// chat recebeu o aviso, e err lista os chats que falharam
+func (m *Monitor) escalateToChats(b *Bot, chats []string, header string, lines []string, group []escalation) (delivered bool, err error) {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, e := range group {
+		if i >= monitorMuteButtons {
+			break
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(m.ackButton(e.id, "✅ Reconhecer "+e.state.Name)))
+	}
+
+	var failed []string
+	for _, target := range chats {
+		chatID, _ := strconv.ParseInt(target, 10, 64)
+		msg := tgbotapi.NewMessage(chatID, header+"\n\n"+strings.Join(lines, "\n"))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+		if _, err := b.API.Send(msg); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", target, err))
+			continue
+		}
+		delivered = true
+	}
+	if len(failed) > 0 {
+		return delivered, fmt.Errorf("%s", strings.Join(failed, "\n"))
+	}
+	return delivered, nil
+}
+
+// notifyEscalatedRecovery avisa as recuperações aos chats dos níveis já disparados
+// no incidente; os emails só recebem o escalonamento
+func (m *Monitor) notifyEscalatedRecovery(b *Bot, recovered []transition) {
+	lines := make(map[string][]string)
+	var chats []string
+	for _, t := range recovered {
+		for i := 0; i < t.state.Escalated && i < len(b.escalation); i++ {
+			if b.escalation[i].Kind != escalateChat {
+				continue
+			}
+			for _, target := range b.escalation[i].Targets {
+				if lines[target] == nil {
+					chats = append(chats, target)
+				}
+				lines[target] = appendUnique(lines[target], fmt.Sprintf("✅ %s voltou após %s", t.state.Name, formatDuration(t.downFor)))
+			}
+		}
+	}
+
+	for _, target := range chats {
+		chatID, _ := strconv.ParseInt(target, 10, 64)
+		b.API.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("🟢 %s\n\n%s", m.title(b), strings.Join(lines[target], "\n"))))
+	}
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// ackButton reconhece o incidente de um resultado, encerrando o escalonamento
+func (m *Monitor) ackButton(resultID, label string) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("incident:%d:%s", m.ID, resultID))
+}
+
+// handleIncidentCallback reconhece um incidente de monitor. O botão aparece no aviso
+// do monitor e nos chats de escalonamento, os únicos que podem usá-lo.
+// Formato: incident:<ID do monitor>:<ID do resultado>
+func (b *Bot) handleIncidentCallback(query *tgbotapi.CallbackQuery, args []string) {
+	if len(args) != 2 {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+		return
+	}
+
+	var m *Monitor
+	if id, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+		b.mu.Lock()
+		m = b.Monitors[id]
+		b.mu.Unlock()
+	}
+	if m == nil {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Monitor não encontrado."))
+		return
+	}
+	if chatID := query.Message.Chat.ID; chatID != m.ChatID && !b.escalationChat(chatID) {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Não autorizado."))
+		return
+	}
+
+	user := userDisplayName(query.From)
+	m.mu.Lock()
+	state, ok := m.states[args[1]]
+	var name, ackedBy string
+	if ok && state.Down {
+		name = state.Name
+		if ackedBy = state.AckedBy; ackedBy == "" {
+			state.AckedBy = user
+		}
+	}
+	m.mu.Unlock()
+
+	switch {
+	case name == "":
+		b.API.Request(tgbotapi.NewCallback(query.ID, "O incidente já foi encerrado."))
+		return
+	case ackedBy != "":
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Já reconhecido por "+ackedBy+"."))
+		return
+	}
+
+	b.monitorStore.put(m)
+	m.updateSummary(b)
+	b.API.Request(tgbotapi.NewCallback(query.ID, "Incidente reconhecido."))
+
+	text := fmt.Sprintf("✅ %s reconheceu o incidente de %s (monitor #%d). O escalonamento foi encerrado.", user, name, m.ID)
+	b.API.Send(tgbotapi.NewMessage(m.ChatID, text))
+	if query.Message.Chat.ID != m.ChatID {
+		b.API.Send(tgbotapi.NewMessage(query.Message.Chat.ID, text))
+	}
+}
@@ -0,0 +1,312 @@
+package bot
+
+import (
+	"LapaTelegramBot/monitor"
+	"LapaTelegramBot/zabbix"
+	"LapaTelegramBot/zabbix/zabbixtest"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// escalationBot devolve um bot com dois níveis de escalonamento: 15min e 1h
+func escalationBot(t *testing.T) *Bot {
+	t.Chdir(t.TempDir())
+	return &Bot{
+		muteStore:  newMuteStore(),
+		quietStore: newQuietStore(),
+		escalation: []escalationLevel{
+			{After: 15 * time.Minute, Kind: escalateChat, Targets: []string{"-100123"}},
+			{After: time.Hour, Kind: escalateEmail, Targets: []string{"gerente@empresa.com"}},
+		},
+	}
+}
+
+// downMonitor devolve um monitor com os hosts fora do ar desde since
+func downMonitor(since time.Time, hosts ...string) *Monitor {
+	m := newTestMonitor()
+	for _, h := range hosts {
+		m.states[h] = &resultState{Name: h, Host: h, Failures: 2, Since: since, Down: true}
+	}
+	return m
+}
+
+func TestEscalate(t *testing.T) {
+	b := escalationBot(t)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	m := downMonitor(now.Add(-20*time.Minute), "SRV01")
+
+	fired := m.escalate(b, now)
+	if len(fired) != 1 || fired[0].level != 0 || fired[0].downFor != 20*time.Minute {
+		t.Fatalf("fired = %+v, esperado o nível 1 após 20min", fired)
+	}
+	if fired := m.escalate(b, now.Add(time.Minute)); len(fired) != 0 {
+		t.Errorf("fired = %+v, esperado que cada nível dispare uma vez", fired)
+	}
+	if fired := m.escalate(b, now.Add(time.Hour)); len(fired) != 1 || fired[0].level != 1 {
+		t.Errorf("fired = %+v, esperado o nível 2", fired)
+	}
+}
+
+func TestEscalateFiresAllOverdueLevels(t *testing.T) {
+	b := escalationBot(t)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	m := downMonitor(now.Add(-2*time.Hour), "SRV01")
+
+	if fired := m.escalate(b, now); len(fired) != 2 || fired[0].level != 0 || fired[1].level != 1 {
+		t.Errorf("fired = %+v, esperado os dois níveis em ordem", fired)
+	}
+}
+
+func TestEscalateSkips(t *testing.T) {
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name  string
+		setup func(b *Bot, s *resultState)
+	}{
+		{name: "reconhecido", setup: func(b *Bot, s *resultState) { s.AckedBy = "Ana" }},
+		{name: "agrupado", setup: func(b *Bot, s *resultState) { s.Parent = "RT-FILIAL-SP" }},
+		{name: "em observação", setup: func(b *Bot, s *resultState) { s.Down = false }},
+		{name: "silenciado", setup: func(b *Bot, s *resultState) {
+			b.muteStore.set(100, hostMute{Host: s.Host, Until: time.Now().Add(time.Hour)})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := escalationBot(t)
+			m := downMonitor(now.Add(-time.Hour), "SRV01")
+			tt.setup(b, m.states["SRV01"])
+			if fired := m.escalate(b, now); len(fired) != 0 {
+				t.Errorf("fired = %+v, esperado nenhum", fired)
+			}
+		})
+	}
+}
+
+func TestEscalateCountsDependents(t *testing.T) {
+	b := escalationBot(t)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	m := downMonitor(now.Add(-20*time.Minute), "RT-FILIAL-SP", "SRV-FILIAL-SP")
+	m.deps = monitor.Dependencies{"srv-filial-sp": "RT-FILIAL-SP"}
+	m.collapse(nil)
+
+	fired := m.escalate(b, now)
+	if len(fired) != 1 || fired[0].id != "RT-FILIAL-SP" || fired[0].dependents != 1 {
+		t.Errorf("fired = %+v, esperado só RT-FILIAL-SP com 1 dependente", fired)
+	}
+}
+
+func TestUnmutedReannounces(t *testing.T) {
+	b := escalationBot(t)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	m := downMonitor(now.Add(-time.Hour), "SRV01")
+
+	b.muteStore.set(100, hostMute{Host: "SRV01", Until: time.Now().Add(time.Hour)})
+	if problems := m.unmuted(b, nil, now); len(problems) != 0 || !m.states["SRV01"].Muted {
+		t.Fatalf("problems = %+v, estado = %+v; esperado silenciado sem aviso", problems, m.states["SRV01"])
+	}
+
+	// O silêncio acabou com o host ainda fora
+	b.muteStore.remove(100, "SRV01")
+	problems := m.unmuted(b, nil, now)
+	if len(problems) != 1 || problems[0].id != "SRV01" || !problems[0].state.Since.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("problems = %+v, esperado novo aviso do SRV01", problems)
+	}
+	if problems := m.unmuted(b, nil, now.Add(5*time.Minute)); len(problems) != 0 {
+		t.Errorf("problems = %+v, esperado um único aviso", problems)
+	}
+
+	// O escalonamento conta do fim do silêncio, e não do início do problema
+	if fired := m.escalate(b, now.Add(10*time.Minute)); len(fired) != 0 {
+		t.Errorf("fired = %+v, esperado nenhum nível 10min após o silêncio", fired)
+	}
+	fired := m.escalate(b, now.Add(15*time.Minute))
+	if len(fired) != 1 || fired[0].level != 0 || fired[0].downFor != 75*time.Minute {
+		t.Errorf("fired = %+v, esperado o nível 1 com 1h15 de problema", fired)
+	}
+}
+
+func TestCallbackAllowedInEscalationChats(t *testing.T) {
+	b := escalationBot(t)
+	b.AllowedChats = map[int64]bool{100: true}
+
+	tests := []struct {
+		chatID int64
+		data   string
+		want   bool
+	}{
+		{chatID: 100, data: "mute:1h:SRV01", want: true},
+		{chatID: 100, data: "incident:1:SRV01", want: true},
+		{chatID: -100123, data: "incident:1:SRV01", want: true},
+		{chatID: -100123, data: "mute:1h:SRV01", want: false},
+		{chatID: -100999, data: "incident:1:SRV01", want: false},
+	}
+	for _, tt := range tests {
+		if got := b.callbackAllowed(tt.chatID, tt.data); got != tt.want {
+			t.Errorf("callbackAllowed(%d, %q) = %v, esperado %v", tt.chatID, tt.data, got, tt.want)
+		}
+	}
+}
+
+// closeWindow fecha a janela de avisos do chat: só abre amanhã
+func closeWindow(b *Bot, chatID int64) {
+	tomorrow := (time.Now().Weekday() + 1) % 7
+	b.quietStore.update(chatID, true, func(q *quietHours) {
+		q.Start, q.End, q.Days = 0, 24*60, []time.Weekday{tomorrow}
+	})
+}
+
+func TestEscalateWaitsForNotifyWindow(t *testing.T) {
+	b := escalationBot(t)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	m := downMonitor(now.Add(-2*time.Hour), "SRV01")
+
+	closeWindow(b, 100)
+	if fired := m.escalate(b, now); len(fired) != 0 {
+		t.Fatalf("fired = %+v, esperado nenhum com a janela fechada", fired)
+	}
+
+	// A janela abriu: os níveis contam a partir daí
+	b.quietStore.remove(100)
+	opened := now.Add(time.Minute)
+	if fired := m.escalate(b, opened); len(fired) != 0 {
+		t.Errorf("fired = %+v, esperado nenhum logo após a janela abrir", fired)
+	}
+	if fired := m.escalate(b, opened.Add(15*time.Minute)); len(fired) != 1 || fired[0].level != 0 {
+		t.Errorf("fired = %+v, esperado o nível 1 15min após a janela abrir", fired)
+	}
+}
+
+func TestHoldAlertUsesInventoryGroups(t *testing.T) {
+	b := escalationBot(t)
+	s := zabbixtest.NewServer()
+	defer s.Close()
+	servers := s.AddGroup("Servidores")
+	s.AddHost(zabbixtest.Host{Host: "SRV01", GroupIDs: []string{servers}})
+	s.AddHost(zabbixtest.Host{Host: "IMP01"})
+
+	z := s.Client()
+	z.Inventory().Interval = time.Hour
+	if err := z.RefreshInventory(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	calls := s.Calls("host.get")
+
+	closeWindow(b, 100)
+	b.quietStore.update(100, false, func(q *quietHours) { q.AlwaysGroups = []string{"servidores"} })
+
+	if b.holdAlert(100, z, "SRV01", noSeverity) {
+		t.Error("SRV01 retido, esperado aviso a qualquer hora pelo grupo")
+	}
+	if !b.holdAlert(100, z, "IMP01", noSeverity) {
+		t.Error("IMP01 avisado, esperado retido até a janela abrir")
+	}
+	if n := s.Calls("host.get"); n != calls {
+		t.Errorf("host.get chamado %d vezes pelos avisos, esperado nenhuma", n-calls)
+	}
+}
+
+// telegramAPI devolve um cliente da API do Telegram servido localmente, que falha
+// os envios para os chats em failChats
+func telegramAPI(t *testing.T, failChats ...string) *tgbotapi.BotAPI {
+	t.Helper()
+	fail := make(map[string]bool)
+	for _, chat := range failChats {
+		fail[chat] = true
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		chat := r.FormValue("chat_id")
+		switch method := path.Base(r.URL.Path); {
+		case method == "getMe":
+			fmt.Fprint(w, `{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"Lapa","username":"lapabot"}}`)
+		case fail[chat]:
+			fmt.Fprint(w, `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`)
+		case method == "sendMessage" || method == "editMessageText":
+			fmt.Fprintf(w, `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":%s}}}`, chat)
+		default:
+			fmt.Fprint(w, `{"ok":true,"result":true}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	api, err := tgbotapi.NewBotAPIWithClient("token", srv.URL+"/bot%s/%s", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
+func TestSendEscalationsRetriesUndelivered(t *testing.T) {
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		name      string
+		targets   []string
+		failChats []string
+		retried   bool
+	}{
+		{name: "entregue", targets: []string{"-100123"}, retried: false},
+		{name: "nenhum chat recebeu", targets: []string{"-100123"}, failChats: []string{"-100123"}, retried: true},
+		{name: "um dos chats recebeu", targets: []string{"-100123", "-100456"}, failChats: []string{"-100456"}, retried: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := escalationBot(t)
+			b.API = telegramAPI(t, tt.failChats...)
+			b.Servers = zabbix.NewServers(&zabbix.Client{})
+			b.escalation[0].Targets = tt.targets
+			m := downMonitor(now.Add(-20*time.Minute), "SRV01")
+
+			fired := m.escalate(b, now)
+			m.retryEscalations(m.sendEscalations(b, fired))
+
+			again := m.escalate(b, now.Add(time.Minute))
+			if retried := len(again) == 1 && again[0].level == 0; retried != tt.retried {
+				t.Errorf("nível 1 disparado de novo = %v, esperado %v (Escalated = %d)", retried, tt.retried, m.states["SRV01"].Escalated)
+			}
+		})
+	}
+}
+
+func TestIncidentCallbackChats(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		chatID int64
+		acked  bool
+	}{
+		{name: "chat do monitor", chatID: 100, acked: true},
+		{name: "chat de escalonamento", chatID: -100123, acked: true},
+		{name: "outro chat autorizado", chatID: 200, acked: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := escalationBot(t)
+			b.API = telegramAPI(t)
+			b.Servers = zabbix.NewServers(&zabbix.Client{})
+			b.AllowedChats = map[int64]bool{100: true, 200: true}
+			b.monitorStore = newMonitorStore()
+			m := downMonitor(now.Add(-20*time.Minute), "SRV01")
+			m.zabbix = b.Servers.Default()
+			b.Monitors = map[int64]*Monitor{m.ID: m}
+
+			query := &tgbotapi.CallbackQuery{
+				ID:      "1",
+				From:    &tgbotapi.User{FirstName: "Ana"},
+				Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: tt.chatID}},
+			}
+			b.handleIncidentCallback(query, []string{fmt.Sprint(m.ID), "SRV01"})
+
+			if acked := m.states["SRV01"].AckedBy != ""; acked != tt.acked {
+				t.Errorf("reconhecido = %v, esperado %v", acked, tt.acked)
+			}
+		})
+	}
+}
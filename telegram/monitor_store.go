@@ -0,0 +1,193 @@
+package bot
+
+import (
+	"LapaTelegramBot/monitor"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// monitorsFile guarda os monitores em execução, ao lado de schedules.json
+const monitorsFile = "monitors.json"
+
+// savedMonitor é a definição de um monitor gravada em monitorsFile
+type savedMonitor struct {
+	ID              int64                   `json:"id"`
+	ChatID          int64                   `json:"chat_id"`
+	IntervalMinutes int                     `json:"interval_minutes"`
+	Server          string                  `json:"server"`
+	Check           monitor.Spec            `json:"check"`
+	SummaryMsgID    int                     `json:"summary_message_id"`
+	LastCheck       time.Time               `json:"last_check"`
+	States          map[string]*resultState `json:"states"`
+}
+
+// monitorStore grava os monitores para que sejam retomados quando o bot reiniciar
+type monitorStore struct {
+	mu       sync.Mutex
+	lastID   int64
+	monitors map[int64]savedMonitor
+}
+
+func newMonitorStore() *monitorStore {
+	return &monitorStore{monitors: make(map[int64]savedMonitor)}
+}
+
+func (s *monitorStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(monitorsFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var saved map[int64]savedMonitor
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	for _, m := range saved {
+		s.lastID = max(s.lastID, m.ID)
+	}
+	for _, m := range saved {
+		// Monitores gravados antes dos IDs eram um por chat, indexados pelo chat
+		if m.ID == 0 {
+			s.lastID++
+			m.ID = s.lastID
+		}
+		s.monitors[m.ID] = m
+	}
+	return nil
+}
+
+// save grava o arquivo. Deve ser chamado com s.mu travado.
+func (s *monitorStore) save() error {
+	data, err := json.MarshalIndent(s.monitors, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(monitorsFile, data, 0644)
+}
+
+// nextID reserva o ID de um novo monitor; os IDs são sequenciais e não se repetem
+func (s *monitorStore) nextID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	return s.lastID
+}
+
+// put grava o estado atual do monitor. Um monitor já parado não é gravado, para
+// que uma checagem em andamento não recrie o registro removido pelo stop.
+func (s *monitorStore) put(m *Monitor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m.ctx.Err() != nil {
+		return
+	}
+	s.monitors[m.ID] = m.saved()
+	if err := s.save(); err != nil {
+		log.Printf("⚠️  Erro ao salvar %s: %v", monitorsFile, err)
+	}
+}
+
+func (s *monitorStore) remove(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.monitors, id)
+	if err := s.save(); err != nil {
+		log.Printf("⚠️  Erro ao salvar %s: %v", monitorsFile, err)
+	}
+}
+
+// all devolve os monitores gravados, em ordem de ID
+func (s *monitorStore) all() []savedMonitor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]savedMonitor, 0, len(s.monitors))
+	for _, m := range s.monitors {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// saved copia a definição e o estado dos resultados do monitor para gravação
+func (m *Monitor) saved() savedMonitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	states := make(map[string]*resultState, len(m.states))
+	for id, state := range m.states {
+		copied := *state
+		states[id] = &copied
+	}
+	return savedMonitor{
+		ID:              m.ID,
+		ChatID:          m.ChatID,
+		IntervalMinutes: m.IntervalMinutes,
+		Server:          m.zabbix.Name,
+		Check:           m.check.Spec(),
+		SummaryMsgID:    m.summaryMsgID,
+		LastCheck:       m.lastCheck,
+		States:          states,
+	}
+}
+
+// initMonitors carrega os monitores gravados e os retoma, avisando cada chat.
+// Monitores de servidores que não estão mais configurados são descartados.
+func (b *Bot) initMonitors() {
+	b.monitorStore = newMonitorStore()
+	if err := b.monitorStore.load(); err != nil {
+		log.Printf("⚠️  Erro ao ler %s: %v", monitorsFile, err)
+		return
+	}
+
+	resumed := make(map[int64][]string)
+	var chats []int64
+	for _, saved := range b.monitorStore.all() {
+		z, ok := b.Servers.Get(saved.Server)
+		if !ok || saved.IntervalMinutes <= 0 || !b.AllowedChats[saved.ChatID] {
+			log.Printf("⚠️  Monitor %d do chat %d descartado: servidor %q não configurado ou chat não autorizado", saved.ID, saved.ChatID, saved.Server)
+			b.monitorStore.remove(saved.ID)
+			continue
+		}
+		check, err := monitor.NewCheck(saved.Check)
+		if err != nil {
+			log.Printf("⚠️  Monitor %d do chat %d descartado: %v", saved.ID, saved.ChatID, err)
+			b.monitorStore.remove(saved.ID)
+			continue
+		}
+
+		m := NewMonitor(saved.ID, saved.ChatID, saved.IntervalMinutes, z, check)
+		m.summaryMsgID = saved.SummaryMsgID
+		m.lastCheck = saved.LastCheck
+		for id, state := range saved.States {
+			m.states[id] = state
+		}
+		b.startMonitor(m)
+
+		log.Printf("Monitor %d retomado para o chat %d (%d min)", m.ID, m.ChatID, m.IntervalMinutes)
+		if _, ok := resumed[m.ChatID]; !ok {
+			chats = append(chats, m.ChatID)
+		}
+		resumed[m.ChatID] = append(resumed[m.ChatID], fmt.Sprintf("• #%d %s, a cada %d min", m.ID, m.check.Describe(), m.IntervalMinutes))
+	}
+
+	for _, chatID := range chats {
+		b.API.Send(tgbotapi.NewMessage(chatID, "🔄 O bot foi reiniciado e os monitores deste chat voltaram a rodar:\n\n"+strings.Join(resumed[chatID], "\n")))
+	}
+}
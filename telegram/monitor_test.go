@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"LapaTelegramBot/monitor"
+	"testing"
+	"time"
+)
+
+// newTestMonitor cria um monitor de ping que avisa na segunda falha seguida
+func newTestMonitor() *Monitor {
+	m := NewMonitor(1, 100, 5, nil, monitor.PingCheck{})
+	m.failThreshold = 2
+	return m
+}
+
+// result monta um resultado de ping do host
+func result(host string, status monitor.Status) monitor.Result {
+	return monitor.Result{ID: host, Name: host, Host: host, Status: status}
+}
+
+func TestMonitorApplyThreshold(t *testing.T) {
+	m := newTestMonitor()
+	start := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+
+	problems, _, _ := m.apply([]monitor.Result{result("SRV01", monitor.StatusProblem)}, start)
+	if len(problems) != 0 || m.states["SRV01"].Failures != 1 {
+		t.Fatalf("primeira falha: problems = %v, estado = %+v; esperado só em observação", problems, m.states["SRV01"])
+	}
+
+	// Sem dados, o estado é mantido
+	m.apply([]monitor.Result{result("SRV01", monitor.StatusUnknown)}, start.Add(5*time.Minute))
+
+	problems, _, _ = m.apply([]monitor.Result{result("SRV01", monitor.StatusProblem)}, start.Add(10*time.Minute))
+	if len(problems) != 1 || !problems[0].state.Down || !problems[0].state.Since.Equal(start) {
+		t.Fatalf("segunda falha: problems = %+v, esperado aviso desde a primeira falha", problems)
+	}
+
+	// Já avisado: não avisa de novo
+	if problems, _, _ = m.apply([]monitor.Result{result("SRV01", monitor.StatusProblem)}, start.Add(15*time.Minute)); len(problems) != 0 {
+		t.Errorf("terceira falha: problems = %+v, esperado nenhum aviso novo", problems)
+	}
+}
+
+func TestMonitorApplyRecovery(t *testing.T) {
+	m := newTestMonitor()
+	start := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+
+	// Uma falha isolada não gera aviso nem recuperação
+	m.apply([]monitor.Result{result("SRV01", monitor.StatusProblem)}, start)
+	if _, recovered, _ := m.apply([]monitor.Result{result("SRV01", monitor.StatusOK)}, start.Add(5*time.Minute)); len(recovered) != 0 {
+		t.Errorf("recovered = %+v, esperado nenhuma recuperação de host não avisado", recovered)
+	}
+	if s := m.states["SRV01"]; s.Failures != 0 || s.Down {
+		t.Errorf("estado = %+v, esperado zerado", s)
+	}
+
+	m.apply([]monitor.Result{result("SRV01", monitor.StatusProblem)}, start.Add(10*time.Minute))
+	m.apply([]monitor.Result{result("SRV01", monitor.StatusProblem)}, start.Add(15*time.Minute))
+	_, recovered, _ := m.apply([]monitor.Result{result("SRV01", monitor.StatusOK)}, start.Add(40*time.Minute))
+	if len(recovered) != 1 || recovered[0].downFor != 30*time.Minute {
+		t.Fatalf("recovered = %+v, esperado recuperação após 30min", recovered)
+	}
+	if m.states["SRV01"].Down {
+		t.Error("estado continua fora após a recuperação")
+	}
+}
+
+func TestMonitorCollapse(t *testing.T) {
+	m := newTestMonitor()
+	m.deps = monitor.Dependencies{"srv-filial-sp": "RT-FILIAL-SP", "imp-filial-sp": "RT-FILIAL-SP"}
+	start := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+
+	down := []monitor.Result{
+		result("RT-FILIAL-SP", monitor.StatusProblem),
+		result("SRV-FILIAL-SP", monitor.StatusProblem),
+		result("IMP-FILIAL-SP", monitor.StatusProblem),
+	}
+	m.apply(down, start)
+	problems, _, _ := m.apply(down, start.Add(5*time.Minute))
+	problems = m.collapse(problems)
+
+	if len(problems) != 3 {
+		t.Fatalf("problems = %+v, esperado 3", problems)
+	}
+	counts, _ := dependents(problems)
+	if counts["rt-filial-sp"] != 2 {
+		t.Errorf("dependentes de RT-FILIAL-SP = %d, esperado 2", counts["rt-filial-sp"])
+	}
+	for _, p := range problems {
+		if p.id != "RT-FILIAL-SP" && p.state.Parent != "RT-FILIAL-SP" {
+			t.Errorf("%s sem o host do qual depende: %+v", p.id, p.state)
+		}
+	}
+
+	// O roteador voltou e o servidor continua fora: o servidor passa a ser avisado sozinho
+	still := []monitor.Result{
+		result("RT-FILIAL-SP", monitor.StatusOK),
+		result("SRV-FILIAL-SP", monitor.StatusProblem),
+		result("IMP-FILIAL-SP", monitor.StatusOK),
+	}
+	problems, _, _ = m.apply(still, start.Add(10*time.Minute))
+	problems = m.collapse(problems)
+	if len(problems) != 1 || problems[0].id != "SRV-FILIAL-SP" || problems[0].state.Parent != "" {
+		t.Errorf("problems = %+v, esperado SRV-FILIAL-SP sozinho", problems)
+	}
+}
+
+func TestMonitorCountsUnknown(t *testing.T) {
+	m := newTestMonitor()
+	start := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+
+	m.apply([]monitor.Result{
+		result("SRV01", monitor.StatusProblem),
+		result("SRV02", monitor.StatusProblem),
+		result("SRV03", monitor.StatusOK),
+	}, start)
+	m.apply([]monitor.Result{
+		result("SRV01", monitor.StatusProblem),
+		result("SRV02", monitor.StatusOK),
+		result("SRV03", monitor.StatusOK),
+		result("SRV04", monitor.StatusProblem),
+	}, start.Add(5*time.Minute))
+
+	// Sem dados para todos: fora, em observação e OK seguem contados como antes
+	m.apply([]monitor.Result{
+		result("SRV01", monitor.StatusUnknown),
+		result("SRV02", monitor.StatusUnknown),
+		result("SRV03", monitor.StatusUnknown),
+		result("SRV04", monitor.StatusUnknown),
+		result("SRV05", monitor.StatusUnknown),
+	}, start.Add(10*time.Minute))
+
+	want := monitorCounts{ok: 0, down: 1, pending: 1, unknown: 3}
+	if c := m.counts(); c != want {
+		t.Errorf("counts = %+v, esperado %+v", c, want)
+	}
+}
+
+func TestMonitorApplyKeepsItemsOfUnknownHost(t *testing.T) {
+	m := NewMonitor(1, 100, 5, nil, monitor.TonerCheck{Threshold: 10})
+	m.failThreshold = 1
+	start := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+
+	toner := monitor.Result{ID: "30001", Name: "IMP01: Toner preto", Host: "IMP01", Status: monitor.StatusProblem}
+	m.apply([]monitor.Result{toner}, start)
+
+	// A consulta dos itens da impressora falhou: o toner baixo continua acompanhado
+	m.apply([]monitor.Result{{ID: "host:10001", Name: "IMP01", Host: "IMP01", Status: monitor.StatusUnknown}}, start.Add(5*time.Minute))
+	if s, ok := m.states["30001"]; !ok || !s.Down {
+		t.Fatalf("estado do item = %+v, esperado mantido fora", s)
+	}
+
+	toner.Status = monitor.StatusOK
+	_, recovered, _ := m.apply([]monitor.Result{toner}, start.Add(10*time.Minute))
+	if len(recovered) != 1 || recovered[0].id != "30001" {
+		t.Errorf("recovered = %+v, esperado a recuperação do item", recovered)
+	}
+	if _, ok := m.states["host:10001"]; ok {
+		t.Error("estado sem dados da impressora continua acompanhado")
+	}
+}
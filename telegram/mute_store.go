@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mutesFile guarda os hosts silenciados de cada chat, ao lado de schedules.json
+const mutesFile = "mutes.json"
+
+// hostMute silencia os avisos dos monitores para um host em um chat
+type hostMute struct {
+	Host string `json:"host"`
+	// Until é o fim do silêncio; zero silencia até o host se recuperar
+	Until     time.Time `json:"until,omitempty"`
+	By        string    `json:"by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// untilRecovery indica que o silêncio termina quando o host se recuperar
+func (m hostMute) untilRecovery() bool {
+	return m.Until.IsZero()
+}
+
+// expired indica que o silêncio com prazo já terminou
+func (m hostMute) expired(now time.Time) bool {
+	return !m.untilRecovery() && !now.Before(m.Until)
+}
+
+// describe descreve até quando o host fica silenciado
+func (m hostMute) describe() string {
+	if m.untilRecovery() {
+		return "até se recuperar"
+	}
+	return "até " + m.Until.Format("02/01 15:04")
+}
+
+// muteStore grava os hosts silenciados por chat, indexados pelo nome do host em minúsculas
+type muteStore struct {
+	mu    sync.Mutex
+	mutes map[int64]map[string]hostMute
+}
+
+func newMuteStore() *muteStore {
+	return &muteStore{mutes: make(map[int64]map[string]hostMute)}
+}
+
+func (s *muteStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(mutesFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Um arquivo com "null" não pode zerar o mapa criado em newMuteStore
+	var saved map[int64]map[string]hostMute
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	for chatID, mutes := range saved {
+		s.mutes[chatID] = mutes
+	}
+	return nil
+}
+
+// save remove os silêncios vencidos e grava o arquivo. Deve ser chamado com s.mu travado.
+func (s *muteStore) save() {
+	now := time.Now()
+	for chatID, mutes := range s.mutes {
+		for key, m := range mutes {
+			if m.expired(now) {
+				delete(mutes, key)
+			}
+		}
+		if len(mutes) == 0 {
+			delete(s.mutes, chatID)
+		}
+	}
+
+	data, err := json.MarshalIndent(s.mutes, "", "  ")
+	if err == nil {
+		err = os.WriteFile(mutesFile, data, 0644)
+	}
+	if err != nil {
+		log.Printf("⚠️  Erro ao salvar %s: %v", mutesFile, err)
+	}
+}
+
+// set silencia o host no chat, substituindo um silêncio anterior
+func (s *muteStore) set(chatID int64, m hostMute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mutes[chatID] == nil {
+		s.mutes[chatID] = make(map[string]hostMute)
+	}
+	s.mutes[chatID][strings.ToLower(m.Host)] = m
+	s.save()
+}
+
+// remove encerra o silêncio do host; ok é falso se o host não estava silenciado
+func (s *muteStore) remove(chatID int64, host string) (ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(host)
+	m, ok := s.mutes[chatID][key]
+	if !ok || m.expired(time.Now()) {
+		return false
+	}
+	delete(s.mutes[chatID], key)
+	s.save()
+	return true
+}
+
+// active devolve o silêncio em vigor para o host no chat
+func (s *muteStore) active(chatID int64, host string) (hostMute, bool) {
+	if host == "" {
+		return hostMute{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.mutes[chatID][strings.ToLower(host)]
+	if !ok || m.expired(time.Now()) {
+		return hostMute{}, false
+	}
+	return m, true
+}
+
+// list devolve os silêncios em vigor no chat, em ordem de host
+func (s *muteStore) list(chatID int64) []hostMute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var list []hostMute
+	for _, m := range s.mutes[chatID] {
+		if !m.expired(now) {
+			list = append(list, m)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Host < list[j].Host })
+	return list
+}
+
+// initMutes carrega os hosts silenciados gravados
+func (b *Bot) initMutes() {
+	b.muteStore = newMuteStore()
+	if err := b.muteStore.load(); err != nil {
+		log.Printf("⚠️  Erro ao ler %s: %v", mutesFile, err)
+	}
+}
@@ -0,0 +1,23 @@
+package bot
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMuteStoreLoadNull(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(mutesFile, []byte("null"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newMuteStore()
+	if err := s.load(); err != nil {
+		t.Fatal(err)
+	}
+	s.set(100, hostMute{Host: "SRV01", Until: time.Now().Add(time.Hour)})
+	if _, ok := s.active(100, "srv01"); !ok {
+		t.Error("SRV01 não ficou silenciado após carregar um arquivo com null")
+	}
+}
@@ -0,0 +1,360 @@
+package bot
+
+import (
+	"LapaTelegramBot/zabbix"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// quietFile guarda as janelas de avisos de cada chat, ao lado de schedules.json
+const quietFile = "quiet_hours.json"
+
+// noSeverity indica um aviso sem severidade do Zabbix, como os dos monitores
+const noSeverity = -1
+
+// weekdayNames são as abreviações dos dias aceitas pelo /notify_window, na ordem de time.Weekday
+var weekdayNames = []string{"dom", "seg", "ter", "qua", "qui", "sex", "sab"}
+
+// quietHours é a janela de avisos de um chat. Fora dela, os avisos dos monitores e
+// os alertas do Zabbix vão para um resumo, enviado quando a janela abre.
+type quietHours struct {
+	// Start e End são os minutos desde a meia-noite em que a janela abre e fecha.
+	// Start maior que End cruza a meia-noite, ex: 22:00-06:00.
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// Days são os dias com avisos; vazio vale todos os dias. Em janelas que cruzam
+	// a meia-noite, vale o dia em que a janela abriu.
+	Days []time.Weekday `json:"days,omitempty"`
+	// AlwaysGroups são os grupos de hosts avisados a qualquer hora
+	AlwaysGroups []string `json:"always_groups,omitempty"`
+	// AlwaysSeverity é a severidade mínima dos alertas do Zabbix avisados a qualquer
+	// hora; zero desativa
+	AlwaysSeverity int `json:"always_severity,omitempty"`
+	// Digest são os avisos guardados fora da janela
+	Digest []digestEntry `json:"digest,omitempty"`
+}
+
+// digestEntry é um aviso guardado para o resumo
+type digestEntry struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// open indica se a janela está aberta no horário informado
+func (q *quietHours) open(now time.Time) bool {
+	minutes := now.Hour()*60 + now.Minute()
+	day := now.Weekday()
+	switch {
+	case q.Start < q.End:
+		if minutes < q.Start || minutes >= q.End {
+			return false
+		}
+	case minutes >= q.Start:
+	case minutes < q.End:
+		// Madrugada de uma janela que abriu no dia anterior
+		day = (day + 6) % 7
+	default:
+		return false
+	}
+
+	if len(q.Days) == 0 {
+		return true
+	}
+	for _, d := range q.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// always indica se o aviso ignora a janela pelo grupo do host ou pela severidade
+func (q *quietHours) always(groups []zabbix.HostGroup, severity int) bool {
+	if q.AlwaysSeverity > 0 && severity >= q.AlwaysSeverity {
+		return true
+	}
+	for _, g := range groups {
+		for _, name := range q.AlwaysGroups {
+			if strings.EqualFold(g.Name, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// describe descreve a janela, ex: "08:00-18:00, seg a sex"
+func (q *quietHours) describe() string {
+	days := "todos os dias"
+	if len(q.Days) > 0 && len(q.Days) < len(weekdayNames) {
+		var names []string
+		for _, d := range q.Days {
+			names = append(names, weekdayNames[d])
+		}
+		days = strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s-%s, %s", formatClock(q.Start), formatClock(q.End), days)
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// parseClock interpreta "8", "08:30" ou "24:00" em minutos desde a meia-noite
+func parseClock(value string) (int, error) {
+	h, m, found := strings.Cut(value, ":")
+	hours, err := strconv.Atoi(h)
+	minutes := 0
+	if err == nil && found {
+		minutes, err = strconv.Atoi(m)
+	}
+	total := hours*60 + minutes
+	if err != nil || hours < 0 || minutes < 0 || minutes > 59 || total > 24*60 {
+		return 0, fmt.Errorf("horário inválido: %s", value)
+	}
+	return total, nil
+}
+
+// parseWindow interpreta o intervalo de horários, ex: "08:00-18:00"
+func parseWindow(value string) (start, end int, err error) {
+	from, to, found := strings.Cut(value, "-")
+	if !found {
+		return 0, 0, fmt.Errorf("horário inválido: %s (use o formato 08:00-18:00)", value)
+	}
+	if start, err = parseClock(from); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(to); err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("a janela %s não tem duração", value)
+	}
+	return start, end, nil
+}
+
+// parseWeekdays interpreta os dias, ex: "seg-sex", "seg,qua,sex", "sab-dom" ou "todos"
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	value = strings.ToLower(strings.NewReplacer("á", "a", " ", "").Replace(value))
+	if value == "todos" {
+		return nil, nil
+	}
+
+	var days []time.Weekday
+	seen := make(map[time.Weekday]bool)
+	for _, part := range strings.Split(value, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := weekdayIndex(from)
+		last := first
+		if isRange {
+			var okTo bool
+			last, okTo = weekdayIndex(to)
+			ok = ok && okTo
+		}
+		if !ok {
+			return nil, fmt.Errorf("dia inválido: %s (use dom, seg, ter, qua, qui, sex, sab)", part)
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			if !seen[d] {
+				seen[d] = true
+				days = append(days, d)
+			}
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func weekdayIndex(name string) (time.Weekday, bool) {
+	for i, n := range weekdayNames {
+		if n == name {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// quietStore grava as janelas de avisos por chat, com os resumos pendentes
+type quietStore struct {
+	mu    sync.Mutex
+	chats map[int64]*quietHours
+}
+
+func newQuietStore() *quietStore {
+	return &quietStore{chats: make(map[int64]*quietHours)}
+}
+
+func (s *quietStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(quietFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Um arquivo com "null" não pode zerar o mapa criado em newQuietStore
+	var saved map[int64]*quietHours
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	for chatID, q := range saved {
+		if q != nil {
+			s.chats[chatID] = q
+		}
+	}
+	return nil
+}
+
+// save grava o arquivo. Deve ser chamado com s.mu travado.
+func (s *quietStore) save() {
+	data, err := json.MarshalIndent(s.chats, "", "  ")
+	if err == nil {
+		err = os.WriteFile(quietFile, data, 0644)
+	}
+	if err != nil {
+		log.Printf("⚠️  Erro ao salvar %s: %v", quietFile, err)
+	}
+}
+
+// get devolve uma cópia da janela do chat
+func (s *quietStore) get(chatID int64) (quietHours, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.chats[chatID]
+	if !ok {
+		return quietHours{}, false
+	}
+	return *q, true
+}
+
+// update altera a janela do chat; create cria uma janela de 24h quando não existe
+func (s *quietStore) update(chatID int64, create bool, fn func(q *quietHours)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.chats[chatID]
+	if !ok {
+		if !create {
+			return false
+		}
+		q = &quietHours{End: 24 * 60}
+		s.chats[chatID] = q
+	}
+	fn(q)
+	s.save()
+	return true
+}
+
+// remove apaga a janela do chat e devolve o resumo pendente
+func (s *quietStore) remove(chatID int64) (digest []digestEntry, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.chats[chatID]
+	if !ok {
+		return nil, false
+	}
+	delete(s.chats, chatID)
+	s.save()
+	return q.Digest, true
+}
+
+// hold guarda o aviso no resumo do chat
+func (s *quietStore) hold(chatID int64, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if q, ok := s.chats[chatID]; ok {
+		q.Digest = append(q.Digest, digestEntry{Time: time.Now(), Text: text})
+		s.save()
+	}
+}
+
+// takeDigests retira os resumos pendentes dos chats com a janela aberta
+func (s *quietStore) takeDigests(now time.Time) map[int64][]digestEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	digests := make(map[int64][]digestEntry)
+	for chatID, q := range s.chats {
+		if len(q.Digest) > 0 && q.open(now) {
+			digests[chatID] = q.Digest
+			q.Digest = nil
+		}
+	}
+	if len(digests) > 0 {
+		s.save()
+	}
+	return digests
+}
+
+// holdAlert indica se o aviso ao chat deve esperar a janela abrir. host e severity
+// permitem os avisos a qualquer hora pelos grupos do host ou pela severidade. Os
+// grupos vêm do inventário, sem consultar a API a cada aviso.
+func (b *Bot) holdAlert(chatID int64, z *zabbix.Client, host string, severity int) bool {
+	q, ok := b.quietStore.get(chatID)
+	if !ok || q.open(time.Now()) {
+		return false
+	}
+	if len(q.AlwaysGroups) == 0 || host == "" {
+		return !q.always(nil, severity)
+	}
+
+	groups, ok := z.CachedHostGroups(host)
+	if !ok {
+		// Sem os grupos, avisa: é melhor acordar alguém do que perder um servidor fora
+		log.Printf("Grupos de %s fora do inventário; aviso enviado fora da janela", host)
+		return false
+	}
+	return !q.always(groups, severity)
+}
+
+// sendDigest envia os avisos guardados fora da janela
+func (b *Bot) sendDigest(chatID int64, digest []digestEntry) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🌅 Resumo dos avisos fora da janela (%d)\n", len(digest))
+	for _, e := range digest {
+		fmt.Fprintf(&sb, "\n🕒 %s\n%s\n", e.Time.Format("02/01 15:04"), e.Text)
+	}
+	sb.WriteString("\nVeja o estado atual com /monitor_list e /problems.")
+
+	for _, part := range splitMessage(sb.String()) {
+		b.API.Send(tgbotapi.NewMessage(chatID, part))
+	}
+}
+
+// runDigests envia os resumos pendentes quando a janela de cada chat abre
+func (b *Bot) runDigests() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		for chatID, digest := range b.quietStore.takeDigests(now) {
+			b.sendDigest(chatID, digest)
+		}
+	}
+}
+
+// initQuietHours carrega as janelas de avisos e inicia o envio dos resumos
+func (b *Bot) initQuietHours() {
+	b.quietStore = newQuietStore()
+	if err := b.quietStore.load(); err != nil {
+		log.Printf("⚠️  Erro ao ler %s: %v", quietFile, err)
+	}
+	go b.runDigests()
+}
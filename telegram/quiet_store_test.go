@@ -0,0 +1,106 @@
+package bot
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// at devolve o horário do dia da semana informado na semana de 31/08/2026 (segunda)
+func at(day time.Weekday, hour, minute int) time.Time {
+	sunday := time.Date(2026, 8, 30, 0, 0, 0, 0, time.Local)
+	return sunday.AddDate(0, 0, int(day)).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestQuietHoursOpen(t *testing.T) {
+	business := &quietHours{Start: 8 * 60, End: 18 * 60, Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}
+	overnight := &quietHours{Start: 22 * 60, End: 6 * 60, Days: []time.Weekday{time.Friday}}
+	allDay := &quietHours{Start: 0, End: 24 * 60}
+
+	tests := []struct {
+		name string
+		q    *quietHours
+		now  time.Time
+		want bool
+	}{
+		{"comercial, seg 08:00", business, at(time.Monday, 8, 0), true},
+		{"comercial, seg 07:59", business, at(time.Monday, 7, 59), false},
+		{"comercial, sex 17:59", business, at(time.Friday, 17, 59), true},
+		{"comercial, sex 18:00", business, at(time.Friday, 18, 0), false},
+		{"comercial, sáb 10:00", business, at(time.Saturday, 10, 0), false},
+		{"madrugada, sex 23:00", overnight, at(time.Friday, 23, 0), true},
+		{"madrugada, sáb 05:59 (abriu na sexta)", overnight, at(time.Saturday, 5, 59), true},
+		{"madrugada, sex 03:00 (abriu na quinta)", overnight, at(time.Friday, 3, 0), false},
+		{"madrugada, sáb 06:00", overnight, at(time.Saturday, 6, 0), false},
+		{"madrugada, sex 12:00", overnight, at(time.Friday, 12, 0), false},
+		{"24h, dom 23:59", allDay, at(time.Sunday, 23, 59), true},
+	}
+	for _, tt := range tests {
+		if got := tt.q.open(tt.now); got != tt.want {
+			t.Errorf("%s: open = %v, esperado %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseWeekdays(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []time.Weekday
+	}{
+		{"todos", nil},
+		{"seg-sex", []time.Weekday{1, 2, 3, 4, 5}},
+		{"sex-seg", []time.Weekday{5, 6, 0, 1}},
+		{"seg, qua,sex", []time.Weekday{1, 3, 5}},
+		{"Sáb-Dom", []time.Weekday{6, 0}},
+		{"seg,seg-ter", []time.Weekday{1, 2}},
+	}
+	for _, tt := range tests {
+		got, err := parseWeekdays(tt.value)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseWeekdays(%q) = %v, %v; esperado %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"segunda", "seg-", "xyz", ""} {
+		if _, err := parseWeekdays(value); err == nil {
+			t.Errorf("parseWeekdays(%q): esperado erro", value)
+		}
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	if start, end, err := parseWindow("22:00-6"); err != nil || start != 22*60 || end != 6*60 {
+		t.Errorf("parseWindow = %d, %d, %v", start, end, err)
+	}
+	for _, value := range []string{"08:00", "08:00-08:00", "25:00-06:00", "08:60-18:00"} {
+		if _, _, err := parseWindow(value); err == nil {
+			t.Errorf("parseWindow(%q): esperado erro", value)
+		}
+	}
+}
+
+func TestQuietStoreLoadNull(t *testing.T) {
+	for _, data := range []string{`null`, `{"100": null}`} {
+		t.Run(data, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile(quietFile, []byte(data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			s := newQuietStore()
+			if err := s.load(); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := s.get(100); ok {
+				t.Error("janela carregada de um valor null")
+			}
+			if !s.update(100, true, func(q *quietHours) { q.Start = 8 * 60 }) {
+				t.Fatal("update não criou a janela")
+			}
+			if q, _ := s.get(100); q.Start != 8*60 {
+				t.Errorf("janela = %+v, esperado início às 08:00", q)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package bot
+
+import (
+	"LapaTelegramBot/config"
+	"LapaTelegramBot/zabbix"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// allServers seleciona todos os servidores nos comandos que agregam resultados
+// (/status_check e /problems). Os demais comandos usam o servidor principal.
+const allServers = "todos"
+
+// chatServersFile guarda os servidores escolhidos com /zbx_use
+const chatServersFile = "zabbix_servers.json"
+
+// initServers carrega o servidor padrão de cada chat: primeiro ZABBIX_CHAT_SERVERS
+// (ex: "123:matriz,456:filial"), depois as escolhas salvas pelo /zbx_use.
+func (b *Bot) initServers() {
+	b.chatServers = make(map[int64]string)
+
+	for _, pair := range strings.Split(config.Get("ZABBIX_CHAT_SERVERS", ""), ",") {
+		chat, name, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			continue
+		}
+		chatID, err := strconv.ParseInt(strings.TrimSpace(chat), 10, 64)
+		if err != nil || !b.validServer(strings.TrimSpace(name)) {
+			log.Printf("⚠️  Entrada inválida em ZABBIX_CHAT_SERVERS: %s", pair)
+			continue
+		}
+		b.chatServers[chatID] = strings.TrimSpace(name)
+	}
+
+	data, err := os.ReadFile(chatServersFile)
+	if err != nil {
+		return
+	}
+	var saved map[int64]string
+	if err := json.Unmarshal(data, &saved); err != nil {
+		log.Printf("⚠️  Erro ao ler %s: %v", chatServersFile, err)
+		return
+	}
+	for chatID, name := range saved {
+		if b.validServer(name) {
+			b.chatServers[chatID] = name
+		}
+	}
+}
+
+// saveChatServers grava as escolhas do /zbx_use. Deve ser chamado com b.mu travado.
+func (b *Bot) saveChatServers() error {
+	data, err := json.MarshalIndent(b.chatServers, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(chatServersFile, data, 0644)
+}
+
+func (b *Bot) validServer(name string) bool {
+	if strings.EqualFold(name, allServers) {
+		return true
+	}
+	_, ok := b.Servers.Get(name)
+	return ok
+}
+
+// extractServerOption remove "--server <nome>" ou "--server=<nome>" do texto do comando
+func extractServerOption(text string) (server string, rest string, found bool) {
+	fields := strings.Fields(text)
+	kept := make([]string, 0, len(fields))
+
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		switch {
+		case strings.HasPrefix(f, "--server="):
+			server, found = strings.TrimPrefix(f, "--server="), true
+		case f == "--server" && i+1 < len(fields):
+			server, found = fields[i+1], true
+			i++
+		default:
+			kept = append(kept, f)
+		}
+	}
+
+	if !found {
+		return "", text, false
+	}
+	return server, strings.Join(kept, " "), true
+}
+
+// zabbixTarget é o servidor Zabbix escolhido para um comando: o do --server, o do
+// /zbx_use do chat ou o principal. É resolvido uma vez em runCommand e entregue ao handler.
+type zabbixTarget struct {
+	// client é o servidor dos comandos que consultam um único servidor. Com "todos",
+	// é o servidor principal.
+	client *zabbix.Client
+	// all indica "todos": os comandos que agregam resultados consultam todos os servidores
+	all bool
+}
+
+// commandHandler trata um comando recebido ou agendado
+type commandHandler func(update tgbotapi.Update, target zabbixTarget)
+
+// withoutZabbix adapta os handlers que não consultam o Zabbix
+func withoutZabbix(handler func(tgbotapi.Update)) commandHandler {
+	return func(update tgbotapi.Update, _ zabbixTarget) {
+		handler(update)
+	}
+}
+
+// runCommand executa o handler no servidor escolhido para a mensagem, aplicando a
+// opção --server, se houver
+func (b *Bot) runCommand(handler commandHandler, update tgbotapi.Update) {
+	b.mu.Lock()
+	selected := b.chatServers[update.Message.Chat.ID]
+	b.mu.Unlock()
+
+	server, text, found := extractServerOption(update.Message.Text)
+	if found {
+		if !b.validServer(server) {
+			b.API.Send(tgbotapi.NewMessage(update.Message.Chat.ID, fmt.Sprintf("❌ Servidor %s não configurado.\nDisponíveis: %s", server, strings.Join(b.Servers.Names(), ", "))))
+			return
+		}
+		update.Message.Text = text
+		selected = server
+	}
+
+	handler(update, b.zabbixTarget(selected))
+}
+
+// zabbixTarget resolve o servidor pelo nome; vazio ou "todos" usam o servidor principal
+func (b *Bot) zabbixTarget(name string) zabbixTarget {
+	if z, ok := b.Servers.Get(name); ok {
+		return zabbixTarget{client: z}
+	}
+	return zabbixTarget{client: b.Zabbix, all: strings.EqualFold(name, allServers)}
+}
+
+// zabbixForChat devolve o cliente do servidor padrão do chat
+func (b *Bot) zabbixForChat(chatID int64) *zabbix.Client {
+	b.mu.Lock()
+	name := b.chatServers[chatID]
+	b.mu.Unlock()
+
+	if z, ok := b.Servers.Get(name); ok {
+		return z
+	}
+	return b.Zabbix
+}
+
+// zabbixTargets devolve os servidores consultados pelos comandos que agregam resultados
+func (b *Bot) zabbixTargets(target zabbixTarget) []*zabbix.Client {
+	if target.all {
+		return b.Servers.All()
+	}
+	return []*zabbix.Client{target.client}
+}
+
+// serverRef identifica um objeto do Zabbix nos botões. Com mais de um servidor,
+// inclui o perfil de origem: "<id>@<servidor>".
+func (b *Bot) serverRef(server, id string) string {
+	if !b.Servers.Multiple() || server == "" {
+		return id
+	}
+	return id + "@" + server
+}
+
+// resolveRef separa o ID e o servidor de uma referência criada por serverRef
+func (b *Bot) resolveRef(chatID int64, ref string) (*zabbix.Client, string) {
+	id, server, found := strings.Cut(ref, "@")
+	if found {
+		if z, ok := b.Servers.Get(server); ok {
+			return z, id
+		}
+	}
+	return b.zabbixForChat(chatID), id
+}
+
+// serverLabel identifica a origem dos dados quando há mais de um servidor
+func (b *Bot) serverLabel(z *zabbix.Client) string {
+	if !b.Servers.Multiple() {
+		return ""
+	}
+	return " [" + z.Name + "]"
+}
+
+func (b *Bot) handleZabbixUse(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	// Exemplo de uso: /zbx_use filial
+	parts := strings.Fields(update.Message.Text)
+	if len(parts) < 2 {
+		b.mu.Lock()
+		current := b.chatServers[chatID]
+		b.mu.Unlock()
+		if current == "" {
+			current = b.Zabbix.Name
+		}
+
+		var rows [][]tgbotapi.InlineKeyboardButton
+		for _, name := range append(b.Servers.Names(), allServers) {
+			label := name
+			if strings.EqualFold(name, current) {
+				label = "✅ " + name
+			}
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(label, "server:use:"+name)))
+		}
+
+		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("🖥️ Servidor Zabbix deste chat: %s\n\nEscolha outro ou use /zbx_use <nome>.\n\"%s\" agrega /status_check e /problems de todos os servidores.", current, allServers))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+		b.API.Send(msg)
+		return
+	}
+
+	b.API.Send(tgbotapi.NewMessage(chatID, b.useServer(chatID, parts[1])))
+}
+
+// handleServerCallback trata os botões do /zbx_use. Formato: server:use:<nome>
+func (b *Bot) handleServerCallback(query *tgbotapi.CallbackQuery, args []string) {
+	if len(args) < 2 || args[0] != "use" {
+		b.API.Request(tgbotapi.NewCallback(query.ID, "Ação desconhecida."))
+		return
+	}
+
+	text := b.useServer(query.Message.Chat.ID, args[1])
+	b.API.Request(tgbotapi.NewCallback(query.ID, ""))
+	b.API.Send(tgbotapi.NewEditMessageText(query.Message.Chat.ID, query.Message.MessageID, text))
+}
+
+// useServer define o servidor padrão do chat e devolve a mensagem de resposta
+func (b *Bot) useServer(chatID int64, name string) string {
+	if !b.validServer(name) {
+		return fmt.Sprintf("❌ Servidor %s não configurado.\nDisponíveis: %s, %s", name, strings.Join(b.Servers.Names(), ", "), allServers)
+	}
+	if z, ok := b.Servers.Get(name); ok {
+		name = z.Name
+	} else {
+		name = allServers
+	}
+
+	b.mu.Lock()
+	b.chatServers[chatID] = name
+	err := b.saveChatServers()
+	b.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Erro ao salvar %s: %v", chatServersFile, err)
+	}
+	return fmt.Sprintf("✅ Servidor Zabbix deste chat: %s", name)
+}
@@ -0,0 +1,188 @@
+package bot
+
+import (
+	"LapaTelegramBot/config"
+	"LapaTelegramBot/webhook"
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// alertMessageTTL é o padrão de WEBHOOK_ALERT_TTL: por quanto tempo o bot lembra as
+// mensagens de um alerta para responder a elas na recuperação e nas atualizações
+const alertMessageTTL = 7 * 24 * time.Hour
+
+// alertMessage são as mensagens de um alerta, por chat, e o horário do envio
+type alertMessage struct {
+	chats map[int64]int
+	sent  time.Time
+}
+
+// initWebhook sobe o receptor do media type webhook do Zabbix quando WEBHOOK_LISTEN
+// estiver definido. Os alertas vão para WEBHOOK_CHAT_IDS ou, se vazio, para os chats autorizados.
+func (b *Bot) initWebhook() {
+	addr := config.Get("WEBHOOK_LISTEN", "")
+	if addr == "" {
+		return
+	}
+
+	secret := config.Get("WEBHOOK_SECRET", "")
+	if secret == "" {
+		log.Println("⚠️  WEBHOOK_LISTEN definido sem WEBHOOK_SECRET, receptor de alertas desativado.")
+		return
+	}
+
+	b.webhookChats = loadAllowedChats(strings.Split(config.Get("WEBHOOK_CHAT_IDS", ""), ","))
+	if len(b.webhookChats) == 0 {
+		b.webhookChats = b.AllowedChats
+	}
+	b.alertTTL = config.GetDuration("WEBHOOK_ALERT_TTL", alertMessageTTL)
+
+	server := webhook.NewServer(addr, secret, b.handleWebhookEvent)
+	go func() {
+		log.Printf("Receptor de alertas do Zabbix ouvindo em %s%s", addr, server.Path)
+		if err := server.ListenAndServe(); err != nil {
+			log.Printf("❌ Receptor de alertas do Zabbix parou: %v", err)
+		}
+	}()
+}
+
+// webhookServerLabel identifica o servidor de origem do alerta quando há mais de um
+func (b *Bot) webhookServerLabel(event webhook.Event) string {
+	if !b.Servers.Multiple() || event.Server == "" {
+		return ""
+	}
+	return " [" + event.Server + "]"
+}
+
+// webhookZabbix devolve o servidor de origem do alerta, ou o principal se não informado
+func (b *Bot) webhookZabbix(event webhook.Event) *zabbix.Client {
+	if z, ok := b.Servers.Get(event.Server); ok {
+		return z
+	}
+	return b.Zabbix
+}
+
+// holdWebhookAlert guarda o alerta no resumo do chat quando ele está fora da janela de avisos
+func (b *Bot) holdWebhookAlert(chatID int64, event webhook.Event, text string) bool {
+	if !b.holdAlert(chatID, b.webhookZabbix(event), event.Host, event.SeverityValue()) {
+		return false
+	}
+	b.quietStore.hold(chatID, text)
+	return true
+}
+
+// handleWebhookEvent entrega o evento recebido aos chats configurados
+func (b *Bot) handleWebhookEvent(event webhook.Event) {
+	log.Printf("Alerta recebido via webhook: evento %s (%s) %s", event.EventID, event.Kind(), event.Name)
+
+	switch event.Kind() {
+	case webhook.KindProblem:
+		b.sendWebhookProblem(event)
+	case webhook.KindRecovery:
+		b.sendWebhookRecovery(event)
+	case webhook.KindUpdate:
+		b.sendWebhookUpdate(event)
+	}
+}
+
+func (b *Bot) sendWebhookProblem(event webhook.Event) {
+	text := fmt.Sprintf("🚨 PROBLEMA\n\n%s %s • %s%s\n%s\n🕒 %s %s",
+		severityIcon(event.SeverityValue()), zabbix.SeverityName(event.SeverityValue()), event.Host, b.webhookServerLabel(event),
+		event.Name,
+		event.Date, event.Time,
+	)
+
+	ref := b.serverRef(event.Server, event.EventID)
+	sent := make(map[int64]int)
+	for chatID := range b.webhookChats {
+		if b.holdWebhookAlert(chatID, event, text) {
+			continue
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = eventKeyboard(ref)
+		m, err := b.API.Send(msg)
+		if err != nil {
+			log.Printf("Erro ao enviar alerta ao chat %d: %v", chatID, err)
+			continue
+		}
+		sent[chatID] = m.MessageID
+	}
+
+	b.rememberAlert(ref, sent, time.Now())
+}
+
+// rememberAlert guarda as mensagens do alerta e descarta as que passaram de alertTTL,
+// para o mapa não crescer com problemas que nunca recebem recuperação
+func (b *Bot) rememberAlert(ref string, chats map[int64]int, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for r, alert := range b.alertMessages {
+		if now.Sub(alert.sent) > b.alertTTL {
+			delete(b.alertMessages, r)
+		}
+	}
+	b.alertMessages[ref] = alertMessage{chats: chats, sent: now}
+}
+
+func (b *Bot) sendWebhookRecovery(event webhook.Event) {
+	ref := b.serverRef(event.Server, event.EventID)
+	b.mu.Lock()
+	original := b.alertMessages[ref].chats
+	delete(b.alertMessages, ref)
+	b.mu.Unlock()
+
+	resolved := fmt.Sprintf("✅ RESOLVIDO\n\n%s %s • %s\n%s",
+		severityIcon(event.SeverityValue()), zabbix.SeverityName(event.SeverityValue()), event.Host,
+		event.Name,
+	)
+	reply := fmt.Sprintf("✅ Resolvido: %s • %s", event.Host, event.Name)
+	if event.Duration != "" {
+		reply += fmt.Sprintf("\n⏱️ Duração: %s", event.Duration)
+	}
+
+	for chatID := range b.webhookChats {
+		messageID, sent := original[chatID]
+		if !sent && b.holdWebhookAlert(chatID, event, reply) {
+			continue
+		}
+		msg := tgbotapi.NewMessage(chatID, reply)
+
+		// Marca o alerta original como resolvido e responde a ele
+		if sent {
+			b.API.Send(tgbotapi.NewEditMessageText(chatID, messageID, resolved))
+			msg.ReplyToMessageID = messageID
+		}
+		b.API.Send(msg)
+	}
+}
+
+func (b *Bot) sendWebhookUpdate(event webhook.Event) {
+	b.mu.Lock()
+	original := b.alertMessages[b.serverRef(event.Server, event.EventID)].chats
+	b.mu.Unlock()
+
+	text := fmt.Sprintf("📝 Atualização: %s • %s\n%s", event.Host, event.Name, event.UpdateAction)
+	if event.UpdateMessage != "" {
+		text += fmt.Sprintf("\n💬 %s", event.UpdateMessage)
+	}
+	if event.UpdateUser != "" {
+		text += fmt.Sprintf("\n👤 %s", event.UpdateUser)
+	}
+
+	for chatID := range b.webhookChats {
+		messageID, sent := original[chatID]
+		if !sent && b.holdWebhookAlert(chatID, event, text) {
+			continue
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		if sent {
+			msg.ReplyToMessageID = messageID
+		}
+		b.API.Send(msg)
+	}
+}
@@ -0,0 +1,24 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRememberAlertDropsOldAlerts(t *testing.T) {
+	b := &Bot{alertMessages: make(map[string]alertMessage), alertTTL: 24 * time.Hour}
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+
+	b.rememberAlert("100", map[int64]int{1: 10}, now.Add(-25*time.Hour))
+	b.rememberAlert("200", map[int64]int{1: 20}, now.Add(-time.Hour))
+	b.rememberAlert("300", map[int64]int{1: 30}, now)
+
+	if _, ok := b.alertMessages["100"]; ok {
+		t.Error("alerta de mais de 24h continua guardado")
+	}
+	for _, ref := range []string{"200", "300"} {
+		if _, ok := b.alertMessages[ref]; !ok {
+			t.Errorf("alerta %s descartado antes do prazo", ref)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package uptime
+
+import "time"
+
+// Outage é uma queda de um host
+type Outage struct {
+	Host       string
+	Start, End time.Time
+	// Ongoing indica que o host ainda não se recuperou; End é o fim do período
+	Ongoing bool
+}
+
+func (o Outage) Duration() time.Duration {
+	return o.End.Sub(o.Start)
+}
+
+// clip limita a queda ao período
+func (o Outage) clip(from, to time.Time) Outage {
+	if o.Start.Before(from) {
+		o.Start = from
+	}
+	if o.End.After(to) {
+		o.End = to
+	}
+	return o
+}
+
+// Report resume a disponibilidade de um host no período
+type Report struct {
+	Host     string
+	From, To time.Time
+	// Availability é o percentual do período com o host no ar
+	Availability float64
+	Downtime     time.Duration
+	// Outages são as quedas do período, cortadas nos limites do período
+	Outages []Outage
+	// MTTR é o tempo médio até a recuperação das quedas já encerradas
+	MTTR    time.Duration
+	Longest Outage
+}
+
+// Report calcula a disponibilidade do host entre from e to. Um período que termina
+// no futuro é cortado no horário atual.
+func (s *Store) Report(server, host string, from, to time.Time) Report {
+	if now := time.Now(); to.After(now) {
+		to = now
+	}
+	r := Report{Host: host, From: from, To: to, Availability: 100}
+	if !to.After(from) {
+		return r
+	}
+
+	var recovered int
+	var repair time.Duration
+	for _, o := range s.Outages(server, host, from, to) {
+		o = o.clip(from, to)
+		r.Outages = append(r.Outages, o)
+		r.Downtime += o.Duration()
+		if o.Duration() > r.Longest.Duration() {
+			r.Longest = o
+		}
+		if !o.Ongoing {
+			recovered++
+			repair += o.Duration()
+		}
+	}
+	if recovered > 0 {
+		r.MTTR = repair / time.Duration(recovered)
+	}
+	r.Availability = 100 * (1 - float64(r.Downtime)/float64(to.Sub(from)))
+	return r
+}
@@ -0,0 +1,161 @@
+package uptime
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// storageFile guarda as mudanças de estado dos hosts, uma por linha, ao lado de schedules.json.
+// O histórico só cresce: cada registro é acrescentado ao fim, sem regravar o arquivo.
+const storageFile = "uptime.jsonl"
+
+// Event é a mudança de estado de um host observada pelos monitores
+type Event struct {
+	Time time.Time `json:"time"`
+	// Server é o perfil do servidor Zabbix do host (ZABBIX_SERVERS)
+	Server string `json:"server,omitempty"`
+	Host   string `json:"host"`
+	// Down indica o início de uma queda; falso, a recuperação
+	Down bool `json:"down"`
+}
+
+type Store struct {
+	mu     sync.Mutex
+	events map[string][]Event // por servidor e host, em ordem de horário
+	// down guarda as quedas em aberto, para ignorar o mesmo evento vindo de dois monitores
+	down  map[string]bool
+	since time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		events: make(map[string][]Event),
+		down:   make(map[string]bool),
+	}
+}
+
+func key(server, host string) string {
+	return strings.ToLower(server) + "/" + strings.ToLower(host)
+}
+
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(storageFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e Event
+		// Uma linha corrompida (ex: queda de energia durante a gravação) não perde o resto
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Host == "" {
+			continue
+		}
+		s.add(e)
+	}
+	return scanner.Err()
+}
+
+// add registra o evento na memória se ele mudar o estado do host. Deve ser chamado com s.mu travado.
+func (s *Store) add(e Event) bool {
+	k := key(e.Server, e.Host)
+	if s.down[k] == e.Down {
+		return false
+	}
+	s.down[k] = e.Down
+	s.events[k] = append(s.events[k], e)
+	if s.since.IsZero() || e.Time.Before(s.since) {
+		s.since = e.Time
+	}
+	return true
+}
+
+// Record grava o início (down) ou o fim de uma queda do host. Eventos que não mudam
+// o estado, como a mesma queda vista por dois monitores, são ignorados.
+func (s *Store) Record(server, host string, down bool, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := Event{Time: at, Server: server, Host: host, Down: down}
+	if !s.add(e) {
+		return nil
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(storageFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// Since devolve o horário do primeiro registro (zero se o histórico estiver vazio)
+func (s *Store) Since() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.since
+}
+
+// Hosts devolve os hosts com registros no servidor
+func (s *Store) Hosts(server string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var hosts []string
+	for _, events := range s.events {
+		if strings.EqualFold(events[0].Server, server) {
+			hosts = append(hosts, events[0].Host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Outages devolve as quedas do host que tocam o período. Uma queda ainda em aberto
+// termina em "to" e fica marcada como Ongoing.
+func (s *Store) Outages(server, host string, from, to time.Time) []Outage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var outages []Outage
+	var current *Outage
+	for _, e := range s.events[key(server, host)] {
+		switch {
+		case e.Down:
+			current = &Outage{Host: e.Host, Start: e.Time}
+		case current != nil:
+			current.End = e.Time
+			outages = append(outages, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		current.End, current.Ongoing = to, true
+		outages = append(outages, *current)
+	}
+
+	var inPeriod []Outage
+	for _, o := range outages {
+		if o.End.After(from) && o.Start.Before(to) {
+			inPeriod = append(inPeriod, o)
+		}
+	}
+	return inPeriod
+}
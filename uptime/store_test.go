@@ -0,0 +1,95 @@
+package uptime
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestReport(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s := NewStore()
+	to := time.Now().Truncate(time.Hour)
+	from := to.Add(-10 * time.Hour)
+
+	record := func(down bool, at time.Time) {
+		t.Helper()
+		if err := s.Record("matriz", "SRV01", down, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Começou antes do período: conta só a parte dentro dele
+	record(true, from.Add(-time.Hour))
+	record(false, from.Add(time.Hour))
+	// A mesma queda vista por dois monitores conta uma vez
+	record(true, from.Add(4*time.Hour))
+	record(true, from.Add(4*time.Hour+time.Minute))
+	record(false, from.Add(7*time.Hour))
+	// Ainda em aberto: termina no fim do período
+	record(true, to.Add(-30*time.Minute))
+
+	r := s.Report("matriz", "srv01", from, to)
+	if len(r.Outages) != 3 {
+		t.Fatalf("outages = %+v, esperado 3", r.Outages)
+	}
+	if want := 4*time.Hour + 30*time.Minute; r.Downtime != want {
+		t.Errorf("Downtime = %v, esperado %v", r.Downtime, want)
+	}
+	if want := 2 * time.Hour; r.MTTR != want {
+		t.Errorf("MTTR = %v, esperado %v (média das quedas encerradas)", r.MTTR, want)
+	}
+	if r.Longest.Duration() != 3*time.Hour {
+		t.Errorf("Longest = %v, esperado 3h", r.Longest.Duration())
+	}
+	if !r.Outages[2].Ongoing {
+		t.Error("a última queda deveria estar em andamento")
+	}
+	if want := 55.0; math.Abs(r.Availability-want) > 0.001 {
+		t.Errorf("Availability = %.2f, esperado %.2f", r.Availability, want)
+	}
+
+	if r := s.Report("filial", "SRV01", from, to); r.Availability != 100 || len(r.Outages) != 0 {
+		t.Errorf("outro servidor: %+v, esperado 100%% sem quedas", r)
+	}
+}
+
+func TestOutagesOutsidePeriod(t *testing.T) {
+	t.Chdir(t.TempDir())
+	s := NewStore()
+	start := time.Date(2026, 9, 1, 10, 0, 0, 0, time.Local)
+	s.Record("", "SRV01", true, start)
+	s.Record("", "SRV01", false, start.Add(time.Hour))
+
+	if o := s.Outages("", "SRV01", start.Add(2*time.Hour), start.Add(3*time.Hour)); len(o) != 0 {
+		t.Errorf("outages = %+v, esperado nenhuma", o)
+	}
+	if o := s.Outages("", "SRV01", start.Add(30*time.Minute), start.Add(3*time.Hour)); len(o) != 1 || o[0].Ongoing {
+		t.Errorf("outages = %+v, esperado uma queda encerrada", o)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Chdir(t.TempDir())
+	start := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	s := NewStore()
+	s.Record("matriz", "SRV01", true, start)
+	s.Record("matriz", "SRV01", false, start.Add(time.Hour))
+	s.Record("matriz", "SRV02", true, start.Add(2*time.Hour))
+
+	loaded := NewStore()
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Since().Equal(start) {
+		t.Errorf("Since = %v, esperado %v", loaded.Since(), start)
+	}
+	if hosts := loaded.Hosts("matriz"); len(hosts) != 2 {
+		t.Errorf("Hosts = %v, esperado SRV01 e SRV02", hosts)
+	}
+	// A queda em aberto continua em aberto: uma nova queda é ignorada
+	loaded.Record("matriz", "SRV02", true, start.Add(3*time.Hour))
+	o := loaded.Outages("matriz", "SRV02", start, start.Add(4*time.Hour))
+	if len(o) != 1 || !o[0].Start.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("outages = %+v, esperado a queda das 12h em aberto", o)
+	}
+}
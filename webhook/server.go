@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxBodySize limita o tamanho do corpo aceito pelo receptor
+const maxBodySize = 1 << 20
+
+// Tipos de evento enviados pelo media type webhook do Zabbix
+const (
+	KindProblem  = "problem"
+	KindRecovery = "recovery"
+	KindUpdate   = "update"
+)
+
+// Event é o corpo JSON enviado pelo script do media type (ver docs/ZABBIX_WEBHOOK.md)
+type Event struct {
+	Secret        string `json:"secret"`
+	EventID       string `json:"event_id"`
+	Value         string `json:"event_value"`         /* 1 - Problema 0 - Recuperação */
+	UpdateStatus  string `json:"event_update_status"` /* 1 - Atualização (ack, mensagem...) */
+	Name          string `json:"event_name"`
+	Severity      string `json:"event_nseverity"`
+	Host          string `json:"host_name"`
+	HostIP        string `json:"host_ip"`
+	Date          string `json:"event_date"`
+	Time          string `json:"event_time"`
+	Duration      string `json:"event_duration"`
+	UpdateAction  string `json:"event_update_action"`
+	UpdateMessage string `json:"event_update_message"`
+	UpdateUser    string `json:"event_update_user"`
+	Server        string `json:"server"` /* Perfil do servidor Zabbix de origem (ZABBIX_SERVERS) */
+}
+
+// Kind classifica o evento em problema, recuperação ou atualização
+func (e Event) Kind() string {
+	switch {
+	case e.UpdateStatus == "1":
+		return KindUpdate
+	case e.Value == "0":
+		return KindRecovery
+	default:
+		return KindProblem
+	}
+}
+
+// SeverityValue devolve a severidade numérica (0 a 5) do evento
+func (e Event) SeverityValue() int {
+	n, _ := strconv.Atoi(e.Severity)
+	return n
+}
+
+// Server recebe os POSTs do media type webhook e os entrega ao Handler
+type Server struct {
+	Addr    string
+	Path    string
+	Secret  string
+	Handler func(Event)
+}
+
+// NewServer cria o receptor que escuta em addr e valida o segredo compartilhado
+func NewServer(addr, secret string, handler func(Event)) *Server {
+	return &Server{Addr: addr, Path: "/zabbix", Secret: secret, Handler: handler}
+}
+
+// ListenAndServe inicia o servidor HTTP; bloqueia até ocorrer um erro
+func (s *Server) ListenAndServe() error {
+	mux := http.NewServeMux()
+	mux.Handle(s.Path, s)
+
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "método não permitido", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "erro ao ler corpo", http.StatusBadRequest)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "JSON inválido", http.StatusBadRequest)
+		return
+	}
+
+	// O segredo pode vir no cabeçalho ou no próprio corpo
+	secret := r.Header.Get("X-Webhook-Secret")
+	if secret == "" {
+		secret = event.Secret
+	}
+	if s.Secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(s.Secret)) != 1 {
+		log.Printf("⚠️  Webhook recusado de %s: segredo inválido", r.RemoteAddr)
+		http.Error(w, "não autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	if event.EventID == "" {
+		http.Error(w, "event_id obrigatório", http.StatusBadRequest)
+		return
+	}
+
+	// Responde imediatamente; o envio ao Telegram não deve estourar o timeout do Zabbix
+	go s.Handler(event)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"ok":true}`))
+}
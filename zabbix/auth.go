@@ -0,0 +1,207 @@
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version representa a versão da API informada por apiinfo.version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Raw   string
+}
+
+// AtLeast indica se a versão é igual ou superior a major.minor
+func (v Version) AtLeast(major, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+func (v Version) String() string {
+	return v.Raw
+}
+
+func parseVersion(raw string) (Version, error) {
+	v := Version{Raw: raw}
+	parts := strings.Split(raw, ".")
+	if len(parts) < 2 {
+		return v, fmt.Errorf("versão inválida: %q", raw)
+	}
+
+	nums := make([]int, 3)
+	for i := 0; i < len(parts) && i < 3; i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return v, fmt.Errorf("versão inválida: %q", raw)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// credentials indica qual token enviar e de que forma
+type credentials struct {
+	token string
+	// header envia o token em "Authorization: Bearer" (Zabbix 6.4+) em vez do campo "auth"
+	header bool
+}
+
+// Version retorna a versão da API do servidor, consultando apiinfo.version
+// somente na primeira chamada.
+func (c *Client) Version(ctx context.Context) (Version, error) {
+	if v, ok := c.cachedVersion(); ok {
+		return v, nil
+	}
+
+	// loginMu evita consultas simultâneas sem travar c.mu durante a chamada HTTP
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+	if v, ok := c.cachedVersion(); ok {
+		return v, nil
+	}
+
+	// apiinfo.version não aceita autenticação
+	resp, err := c.callWithRetry(ctx, "apiinfo.version", []string{}, credentials{})
+	if err != nil {
+		return Version{}, err
+	}
+
+	var raw string
+	if err := unmarshal("apiinfo.version", resp, &raw); err != nil {
+		return Version{}, err
+	}
+
+	v, err := parseVersion(raw)
+	if err != nil {
+		return Version{}, &DecodeError{Method: "apiinfo.version", Err: err}
+	}
+
+	c.mu.Lock()
+	c.version = &v
+	c.mu.Unlock()
+	return v, nil
+}
+
+func (c *Client) cachedVersion() (Version, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.version == nil {
+		return Version{}, false
+	}
+	return *c.version, true
+}
+
+// credentials devolve o token a ser usado na próxima chamada, realizando
+// user.login quando o cliente trabalha com usuário e senha.
+func (c *Client) credentials(ctx context.Context) (credentials, error) {
+	v, err := c.Version(ctx)
+	if err != nil {
+		return credentials{}, err
+	}
+	header := v.AtLeast(6, 4)
+
+	if c.Token != "" {
+		return credentials{token: c.Token, header: header}, nil
+	}
+
+	if c.Username == "" {
+		return credentials{}, errors.New("zabbix: defina ZABBIX_API_TOKEN ou ZABBIX_USER e ZABBIX_PASSWORD")
+	}
+
+	session, err := c.ensureSession(ctx, v)
+	if err != nil {
+		return credentials{}, err
+	}
+	return credentials{token: session, header: header}, nil
+}
+
+// ensureSession devolve a sessão atual ou abre uma nova. Um único user.login é
+// feito por vez: quem espera reaproveita a sessão aberta pela outra goroutine.
+func (c *Client) ensureSession(ctx context.Context, v Version) (string, error) {
+	if session := c.currentSession(); session != "" {
+		return session, nil
+	}
+
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+	if session := c.currentSession(); session != "" {
+		return session, nil
+	}
+
+	session, err := c.login(ctx, v)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.session = session
+	c.mu.Unlock()
+	return session, nil
+}
+
+func (c *Client) currentSession() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session
+}
+
+// login abre uma nova sessão através de user.login
+func (c *Client) login(ctx context.Context, v Version) (string, error) {
+	// O parâmetro "user" foi renomeado para "username" no Zabbix 5.4
+	userField := "user"
+	if v.AtLeast(5, 4) {
+		userField = "username"
+	}
+
+	params := map[string]string{
+		userField:  c.Username,
+		"password": c.Password,
+	}
+
+	resp, err := c.callWithRetry(ctx, "user.login", params, credentials{})
+	if err != nil {
+		return "", err
+	}
+
+	var session string
+	if err := unmarshal("user.login", resp, &session); err != nil {
+		return "", err
+	}
+	return session, nil
+}
+
+// usesLogin indica se o cliente se autentica por sessão de user.login
+func (c *Client) usesLogin() bool {
+	return c.Token == "" && c.Username != ""
+}
+
+// dropSession descarta a sessão expirada, caso outra goroutine ainda não tenha renovado
+func (c *Client) dropSession(expired string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == expired {
+		c.session = ""
+	}
+}
+
+// isSessionExpired identifica os erros que o Zabbix devolve para sessões encerradas
+func isSessionExpired(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	text := strings.ToLower(apiErr.Message + " " + apiErr.Data)
+	return strings.Contains(text, "session terminated") ||
+		strings.Contains(text, "re-login") ||
+		strings.Contains(text, "not authorised") ||
+		strings.Contains(text, "not authorized")
+}
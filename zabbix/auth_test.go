@@ -0,0 +1,244 @@
+package zabbix_test
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// authRequest é uma requisição recebida por authServer
+type authRequest struct {
+	Method        string                     `json:"method"`
+	Params        map[string]json.RawMessage `json:"-"`
+	Auth          string                     `json:"auth"`
+	Authorization string                     `json:"-"`
+}
+
+// authServer responde apiinfo.version, user.login e host.get, registrando as
+// requisições e as sessões abertas
+type authServer struct {
+	*httptest.Server
+	version string
+	// loginGate, se definido, segura as respostas de user.login até ser fechado
+	loginGate chan struct{}
+
+	mu       sync.Mutex
+	requests []authRequest
+	sessions map[string]bool
+}
+
+func newAuthServer(t *testing.T, version string) *authServer {
+	t.Helper()
+	s := &authServer{version: version, sessions: make(map[string]bool)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *authServer) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		authRequest
+		ID     int64           `json:"id"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.authRequest.Authorization = r.Header.Get("Authorization")
+	json.Unmarshal(req.Params, &req.authRequest.Params)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req.authRequest)
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	switch req.Method {
+	case "apiinfo.version":
+		resp["result"] = s.version
+	case "user.login":
+		session := "sessao" + strconv.Itoa(len(s.requests))
+		s.sessions[session] = true
+		resp["result"] = session
+	default:
+		token := req.Auth
+		if token == "" && len(req.authRequest.Authorization) > len("Bearer ") {
+			token = req.authRequest.Authorization[len("Bearer "):]
+		}
+		if token == "token" || s.sessions[token] {
+			resp["result"] = []interface{}{}
+		} else {
+			resp["error"] = map[string]interface{}{"code": -32602, "message": "Invalid params.", "data": "Session terminated, re-login, please."}
+		}
+	}
+	s.mu.Unlock()
+
+	if req.Method == "user.login" && s.loginGate != nil {
+		<-s.loginGate
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// expireSessions encerra todas as sessões abertas, como um logout no Zabbix
+func (s *authServer) expireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]bool)
+}
+
+// calls conta as requisições do método
+func (s *authServer) calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, r := range s.requests {
+		if r.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// last devolve a última requisição do método
+func (s *authServer) last(t *testing.T, method string) authRequest {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		if s.requests[i].Method == method {
+			return s.requests[i]
+		}
+	}
+	t.Fatalf("nenhuma requisição %s", method)
+	return authRequest{}
+}
+
+func TestLoginAndAuthStyleByVersion(t *testing.T) {
+	tests := []struct {
+		version   string
+		userField string
+		header    bool
+	}{
+		{version: "5.2.0", userField: "user"},
+		{version: "6.0.20", userField: "username"},
+		{version: "6.4.0", userField: "username", header: true},
+		{version: "7.0.0", userField: "username", header: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			s := newAuthServer(t, tt.version)
+			c := &zabbix.Client{URL: s.URL, Username: "Admin", Password: "zabbix"}
+
+			if _, err := c.Call(context.Background(), "host.get", map[string]interface{}{}); err != nil {
+				t.Fatal(err)
+			}
+
+			if login := s.last(t, "user.login"); string(login.Params[tt.userField]) != `"Admin"` {
+				t.Errorf("user.login = %v, esperado o campo %q", login.Params, tt.userField)
+			}
+
+			get := s.last(t, "host.get")
+			if tt.header {
+				if get.Auth != "" || get.Authorization == "" {
+					t.Errorf("auth = %q, Authorization = %q; esperado o token só no cabeçalho", get.Auth, get.Authorization)
+				}
+			} else if get.Auth == "" || get.Authorization != "" {
+				t.Errorf("auth = %q, Authorization = %q; esperado o token só no campo auth", get.Auth, get.Authorization)
+			}
+		})
+	}
+}
+
+func TestCallWithTokenSkipsLogin(t *testing.T) {
+	s := newAuthServer(t, "7.0.0")
+	c := &zabbix.Client{URL: s.URL, Token: "token"}
+
+	if _, err := c.Call(context.Background(), "host.get", map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.calls("user.login"); n != 0 {
+		t.Errorf("user.login chamado %d vezes com token, esperado nenhuma", n)
+	}
+	if get := s.last(t, "host.get"); get.Authorization != "Bearer token" {
+		t.Errorf("Authorization = %q, esperado o token", get.Authorization)
+	}
+}
+
+func TestCallLogsInAgainWhenSessionExpires(t *testing.T) {
+	s := newAuthServer(t, "6.0.20")
+	c := &zabbix.Client{URL: s.URL, Username: "Admin", Password: "zabbix"}
+	ctx := context.Background()
+
+	if _, err := c.Call(ctx, "host.get", map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	s.expireSessions()
+	if _, err := c.Call(ctx, "host.get", map[string]interface{}{}); err != nil {
+		t.Fatalf("após expirar a sessão: %v", err)
+	}
+	if n := s.calls("user.login"); n != 2 {
+		t.Errorf("user.login chamado %d vezes, esperado 2", n)
+	}
+	if n := s.calls("host.get"); n != 3 {
+		t.Errorf("host.get chamado %d vezes, esperado 3 (a chamada rejeitada é repetida uma vez)", n)
+	}
+	if n := s.calls("apiinfo.version"); n != 1 {
+		t.Errorf("apiinfo.version chamado %d vezes, esperado 1", n)
+	}
+}
+
+func TestLoginDoesNotHoldClientLock(t *testing.T) {
+	s := newAuthServer(t, "7.0.0")
+	s.loginGate = make(chan struct{})
+	// Libera o user.login mesmo se o teste falhar, para o servidor poder fechar
+	release := sync.OnceFunc(func() { close(s.loginGate) })
+	defer release()
+	c := &zabbix.Client{URL: s.URL, Username: "bot", Password: "senha"}
+	ctx := context.Background()
+	if _, err := c.Version(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetHosts(ctx)
+			errs <- err
+		}()
+	}
+	for s.calls("user.login") == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Com o user.login pendente, a versão em cache continua disponível
+	done := make(chan struct{})
+	go func() {
+		c.Version(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Version bloqueado pelo user.login em andamento")
+	}
+
+	release()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := s.calls("user.login"); n != 1 {
+		t.Errorf("user.login chamado %d vezes, esperado 1 para chamadas simultâneas", n)
+	}
+}
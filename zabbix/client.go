@@ -1,68 +1,237 @@
 package zabbix
 
 import (
+	"LapaTelegramBot/config"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Client struct {
+	// Name é o nome do perfil em ZABBIX_SERVERS, usado para identificar a origem dos dados
+	Name  string
 	URL   string
 	Token string
+
+	// Username e Password são usados em user.login quando não há Token definido
+	Username string
+	Password string
+
+	// HTTPClient é o cliente usado nas chamadas. Pode ser substituído para
+	// ajustar transporte, proxy ou timeout.
+	HTTPClient *http.Client
+	// MaxRetries é a quantidade de novas tentativas em falhas transitórias
+	MaxRetries int
+	// RetryBackoff é a espera antes da primeira nova tentativa; dobra a cada tentativa
+	RetryBackoff time.Duration
+
+	// Groups é o mapeamento de grupos por nome usado pelas consultas do bot
+	Groups Groups
+	// AllowedScripts são os nomes dos scripts globais que o bot pode executar
+	AllowedScripts []string
+
+	// inventory é o cache de hosts, grupos e interfaces (ver RunInventory)
+	inventory *Inventory
+
+	lastID atomic.Int64
+
+	// loginMu serializa apiinfo.version e user.login; mu protege só os campos abaixo
+	loginMu  sync.Mutex
+	mu       sync.Mutex
+	version  *Version
+	session  string
+	groupIDs map[string]string
 }
 
 type request struct {
 	Jsonrpc string      `json:"jsonrpc"`
 	Method  string      `json:"method"`
 	Params  interface{} `json:"params"`
-	ID      int         `json:"id"`
+	ID      int64       `json:"id"`
 	Auth    string      `json:"auth,omitempty"`
 }
 
 type response struct {
 	Result json.RawMessage `json:"result"`
 	Error  *rpcError       `json:"error,omitempty"`
+	ID     int64           `json:"id"`
 }
 
 type rpcError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	Data    string `json:"data"`
 }
 
+// NewClient cria o cliente a partir das variáveis ZABBIX_* do ambiente
 func NewClient() *Client {
-	url := os.Getenv("ZABBIX_API_URL")
-	token := os.Getenv("ZABBIX_API_TOKEN")
+	return newProfileClient(defaultServerName, "")
+}
 
+// newProfileClient cria o cliente do perfil informado. Com env vazio são lidas as
+// variáveis globais; caso contrário, ZABBIX_<PERFIL>_* (ver profileEnv).
+func newProfileClient(name string, env profileEnv) *Client {
 	return &Client{
-		URL:   url,
-		Token: token,
+		Name:     name,
+		URL:      os.Getenv(env.own("ZABBIX_API_URL")),
+		Token:    os.Getenv(env.own("ZABBIX_API_TOKEN")),
+		Username: os.Getenv(env.own("ZABBIX_USER")),
+		Password: os.Getenv(env.own("ZABBIX_PASSWORD")),
+		HTTPClient: &http.Client{
+			Timeout: config.GetDuration(env.key("ZABBIX_TIMEOUT"), 15*time.Second),
+		},
+		MaxRetries:     config.GetInt(env.key("ZABBIX_RETRIES"), 2),
+		RetryBackoff:   config.GetDuration(env.key("ZABBIX_RETRY_BACKOFF"), 500*time.Millisecond),
+		Groups:         loadGroups(env),
+		AllowedScripts: config.SplitList(os.Getenv(env.key("ZABBIX_ALLOWED_SCRIPTS"))),
+		inventory:      newInventory(config.GetDuration(env.key("ZABBIX_INVENTORY_INTERVAL"), 5*time.Minute)),
+	}
+}
+
+// Call executa um método autenticado da API JSON-RPC do Zabbix. Quando o cliente
+// usa sessão de user.login e ela expira, um novo login é feito e a chamada repetida.
+func (c *Client) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	cred, err := c.credentials(ctx)
+	if err != nil {
+		return nil, err
 	}
+
+	result, err := c.callWithRetry(ctx, method, params, cred)
+	if err != nil && c.usesLogin() && isSessionExpired(err) {
+		c.dropSession(cred.token)
+		if cred, err = c.credentials(ctx); err != nil {
+			return nil, err
+		}
+		return c.callWithRetry(ctx, method, params, cred)
+	}
+	return result, err
+}
+
+// callWithRetry repete a chamada em falhas transitórias (rede, timeout e
+// HTTP 5xx/429) enquanto o contexto permitir. Só os métodos de leitura são
+// repetidos (ver retryable): uma falha de rede não diz se o Zabbix aplicou a escrita.
+func (c *Client) callWithRetry(ctx context.Context, method string, params interface{}, cred credentials) (json.RawMessage, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			wait := c.RetryBackoff << (attempt - 1)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		result, err := c.do(ctx, method, params, cred)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if !retryable(method) || !isTransient(ctx, err) {
+			break
+		}
+	}
+
+	return nil, lastErr
 }
 
-func (c *Client) Call(method string, params interface{}) (json.RawMessage, error) {
+// do realiza uma única chamada HTTP ao Zabbix
+func (c *Client) do(ctx context.Context, method string, params interface{}, cred credentials) (json.RawMessage, error) {
 	req := request{
 		Jsonrpc: "2.0",
 		Method:  method,
 		Params:  params,
-		ID:      1,
-		Auth:    c.Token,
+		ID:      c.lastID.Add(1),
+	}
+	if cred.token != "" && !cred.header {
+		req.Auth = cred.token
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("zabbix %s: erro ao serializar requisição: %w", method, err)
 	}
 
-	body, _ := json.Marshal(req)
-	resp, err := http.Post(c.URL, "application/json", bytes.NewBuffer(body))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("zabbix %s: %w", method, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json-rpc")
+	if cred.token != "" && cred.header {
+		httpReq.Header.Set("Authorization", "Bearer "+cred.token)
+	}
 
+	resp, err := c.httpClient().Do(httpReq)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("zabbix %s: %w", method, err)
 	}
 	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &HTTPError{Method: method, StatusCode: resp.StatusCode, Status: resp.Status, Body: string(snippet)}
+	}
+
 	var r response
-	json.NewDecoder(resp.Body).Decode(&r)
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, &DecodeError{Method: method, Err: err}
+	}
 
 	if r.Error != nil {
-		return nil, errors.New(r.Error.Message)
+		return nil, &APIError{Method: method, Code: r.Error.Code, Message: r.Error.Message, Data: r.Error.Data}
+	}
+
+	if r.ID != req.ID {
+		return nil, &DecodeError{Method: method, Err: fmt.Errorf("id da resposta (%d) difere do id da requisição (%d)", r.ID, req.ID)}
 	}
 
 	return r.Result, nil
 }
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// retryable indica se o método pode ser repetido sem efeitos colaterais. Métodos
+// como script.execute, host.create e event.acknowledge executariam a ação duas vezes.
+func retryable(method string) bool {
+	_, action, _ := strings.Cut(method, ".")
+	return strings.HasPrefix(action, "get") || method == "apiinfo.version"
+}
+
+// isTransient indica se vale a pena repetir a chamada que falhou com err
+func isTransient(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var apiErr *APIError
+	var decodeErr *DecodeError
+	if errors.As(err, &apiErr) || errors.As(err, &decodeErr) {
+		return false
+	}
+
+	// Erros de rede (conexão recusada, reset, timeout do http.Client)
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,62 @@
+package zabbix_test
+
+import (
+	"LapaTelegramBot/zabbix"
+	"LapaTelegramBot/zabbix/zabbixtest"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T) (*zabbixtest.Server, *zabbix.Client) {
+	t.Helper()
+	s := zabbixtest.NewServer()
+	t.Cleanup(s.Close)
+
+	c := s.Client()
+	c.MaxRetries = 2
+	c.RetryBackoff = time.Millisecond
+	return s, c
+}
+
+func TestCallRetriesReadMethods(t *testing.T) {
+	s, c := newTestClient(t)
+	s.FailHTTP("host.get", http.StatusServiceUnavailable)
+
+	_, err := c.Call(context.Background(), "host.get", map[string]interface{}{})
+	var httpErr *zabbix.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("erro = %v, esperado HTTP 503", err)
+	}
+	if n := s.Calls("host.get"); n != 3 {
+		t.Errorf("host.get chamado %d vezes, esperado 3", n)
+	}
+}
+
+func TestCallDoesNotRetryWrites(t *testing.T) {
+	for _, method := range []string{"script.execute", "host.create", "host.update", "maintenance.create", "event.acknowledge"} {
+		s, c := newTestClient(t)
+		s.FailHTTP(method, http.StatusBadGateway)
+
+		if _, err := c.Call(context.Background(), method, map[string]interface{}{}); err == nil {
+			t.Fatalf("%s: esperado erro", method)
+		}
+		if n := s.Calls(method); n != 1 {
+			t.Errorf("%s chamado %d vezes, esperado 1", method, n)
+		}
+	}
+}
+
+func TestCallDoesNotRetryAPIErrors(t *testing.T) {
+	s, c := newTestClient(t)
+	s.Fail("host.get", zabbixtest.Error{Code: -32602, Message: "Invalid params.", Data: "Invalid parameter."})
+
+	if _, err := c.Call(context.Background(), "host.get", map[string]interface{}{}); err == nil {
+		t.Fatal("esperado erro")
+	}
+	if n := s.Calls("host.get"); n != 1 {
+		t.Errorf("host.get chamado %d vezes, esperado 1", n)
+	}
+}
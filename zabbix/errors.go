@@ -0,0 +1,59 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// errEmptyResult indica uma resposta de sucesso sem os dados esperados
+var errEmptyResult = errors.New("resultado vazio")
+
+// HTTPError é retornado quando o front-end do Zabbix responde com status diferente de 200
+type HTTPError struct {
+	Method     string
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("zabbix %s: resposta HTTP %s", e.Method, e.Status)
+}
+
+// DecodeError é retornado quando a resposta não é um JSON-RPC válido
+type DecodeError struct {
+	Method string
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("zabbix %s: resposta inválida: %v", e.Method, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// APIError representa o objeto "error" devolvido pela API JSON-RPC do Zabbix
+type APIError struct {
+	Method  string
+	Code    int
+	Message string
+	Data    string
+}
+
+func (e *APIError) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("zabbix %s: %s (%d): %s", e.Method, e.Message, e.Code, e.Data)
+	}
+	return fmt.Sprintf("zabbix %s: %s (%d)", e.Method, e.Message, e.Code)
+}
+
+// unmarshal decodifica o resultado de um método, convertendo falhas em DecodeError
+func unmarshal(method string, data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return &DecodeError{Method: method, Err: err}
+	}
+	return nil
+}
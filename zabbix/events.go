@@ -0,0 +1,36 @@
+package zabbix
+
+import "context"
+
+// Ações de event.acknowledge; podem ser combinadas com OU binário
+const (
+	ActionClose          = 1
+	ActionAcknowledge    = 2
+	ActionMessage        = 4
+	ActionChangeSeverity = 8
+	ActionUnacknowledge  = 16
+)
+
+// Acknowledgement descreve uma atualização de evento enviada por event.acknowledge
+type Acknowledgement struct {
+	Action   int
+	Message  string
+	Severity int
+}
+
+// AcknowledgeEvent aplica as ações de ack sobre os eventos informados
+func (c *Client) AcknowledgeEvent(ctx context.Context, eventIDs []string, ack Acknowledgement) error {
+	params := map[string]interface{}{
+		"eventids": eventIDs,
+		"action":   ack.Action,
+	}
+	if ack.Action&ActionMessage != 0 {
+		params["message"] = ack.Message
+	}
+	if ack.Action&ActionChangeSeverity != 0 {
+		params["severity"] = ack.Severity
+	}
+
+	_, err := c.Call(ctx, "event.acknowledge", params)
+	return err
+}
@@ -0,0 +1,144 @@
+package zabbix
+
+import (
+	"LapaTelegramBot/config"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Groups reúne os nomes de grupos e keys de itens que variam de um ambiente para outro
+type Groups struct {
+	// Printers é o grupo das impressoras usado em /printers_counter
+	Printers string
+	// Protheus é o grupo dos servidores com os serviços do Protheus
+	Protheus string
+	// ProtheusKey é o trecho da key dos itens de serviço do Protheus
+	ProtheusKey string
+	// MonitorExclude são os grupos ignorados pelo /status_monitor
+	MonitorExclude []string
+}
+
+// loadGroups lê o mapeamento de grupos das variáveis de ambiente do perfil
+func loadGroups(env profileEnv) Groups {
+	return Groups{
+		Printers:       config.Get(env.key("ZABBIX_PRINTERS_GROUP"), "Impressoras"),
+		Protheus:       config.Get(env.key("ZABBIX_PROTHEUS_GROUP"), "Protheus"),
+		ProtheusKey:    config.Get(env.key("ZABBIX_PROTHEUS_ITEM_KEY"), "TOTVS"),
+		MonitorExclude: config.SplitList(config.Get(env.key("MONITOR_EXCLUDE_GROUPS"), "Applications,Impressoras")),
+	}
+}
+
+// names devolve todos os grupos configurados, sem repetição
+func (g Groups) names() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, n := range append([]string{g.Printers, g.Protheus}, g.MonitorExclude...) {
+		if n != "" && !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// ResolveGroups busca via hostgroup.get os IDs de todos os grupos configurados e os
+// guarda em cache. Deve ser chamado na inicialização; grupos inexistentes geram erro.
+func (c *Client) ResolveGroups(ctx context.Context) error {
+	names := c.Groups.names()
+	if len(names) == 0 {
+		return nil
+	}
+
+	ids, err := c.GroupIDs(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, n := range names {
+		if _, ok := ids[n]; !ok {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("grupos não encontrados no Zabbix: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// GroupID devolve o ID do grupo com o nome informado, usando o cache quando possível
+func (c *Client) GroupID(ctx context.Context, name string) (string, error) {
+	ids, err := c.GroupIDs(ctx, []string{name})
+	if err != nil {
+		return "", err
+	}
+
+	id, ok := ids[name]
+	if !ok {
+		return "", fmt.Errorf("grupo %q não encontrado no Zabbix", name)
+	}
+	return id, nil
+}
+
+// GroupIDs resolve vários nomes de grupo de uma vez. Nomes inexistentes ficam fora do mapa.
+func (c *Client) GroupIDs(ctx context.Context, names []string) (map[string]string, error) {
+	ids := make(map[string]string)
+	var pending []string
+
+	c.mu.Lock()
+	for _, n := range names {
+		if id, ok := c.groupIDs[n]; ok {
+			ids[n] = id
+		} else {
+			pending = append(pending, n)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(pending) == 0 {
+		return ids, nil
+	}
+
+	params := map[string]interface{}{
+		"output": []string{"groupid", "name"},
+		"filter": map[string]interface{}{"name": pending},
+	}
+
+	resp, err := c.Call(ctx, "hostgroup.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []struct {
+		Groupid string `json:"groupid"`
+		Name    string `json:"name"`
+	}
+	if err := unmarshal("hostgroup.get", resp, &groups); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.groupIDs == nil {
+		c.groupIDs = make(map[string]string)
+	}
+	for _, g := range groups {
+		c.groupIDs[g.Name] = g.Groupid
+		ids[g.Name] = g.Groupid
+	}
+	return ids, nil
+}
+
+// groupsSelector devolve o parâmetro de host.get que traz os grupos do host e o
+// campo correspondente na resposta; selectGroups foi substituído no Zabbix 6.2
+func (c *Client) groupsSelector(ctx context.Context) (param string, field string, err error) {
+	v, err := c.Version(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if v.AtLeast(6, 2) {
+		return "selectHostGroups", "hostgroups", nil
+	}
+	return "selectGroups", "groups", nil
+}
@@ -0,0 +1,32 @@
+package zabbix_test
+
+import (
+	"LapaTelegramBot/zabbix"
+	"context"
+	"testing"
+)
+
+func TestHostGroupsParamByVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		param   string
+	}{
+		{version: "6.0.20", param: "selectGroups"},
+		{version: "6.2.0", param: "selectHostGroups"},
+		{version: "7.0.0", param: "selectHostGroups"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			s := newAuthServer(t, tt.version)
+			c := &zabbix.Client{URL: s.URL, Token: "token"}
+
+			if _, err := c.GetHostsExcludingGroups(context.Background(), nil); err != nil {
+				t.Fatal(err)
+			}
+			if get := s.last(t, "host.get"); get.Params[tt.param] == nil {
+				t.Errorf("host.get sem %s: %v", tt.param, get.Params)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// HistoryPoint é um valor do histórico (ou a média de uma trend) no instante Clock
+type HistoryPoint struct {
+	Clock time.Time
+	Value float64
+}
+
+// GetHistory devolve os valores numéricos do item no período via history.get, em ordem cronológica
+func (c *Client) GetHistory(ctx context.Context, item Item, from, till time.Time) ([]HistoryPoint, error) {
+	if !item.Numeric() {
+		return nil, fmt.Errorf("item %s não é numérico", item.Key)
+	}
+
+	history, _ := strconv.Atoi(item.ValueType)
+	params := map[string]interface{}{
+		"output":    "extend",
+		"history":   history,
+		"itemids":   []string{item.Itemid},
+		"time_from": from.Unix(),
+		"time_till": till.Unix(),
+		"sortfield": "clock",
+		"sortorder": "ASC",
+	}
+
+	resp, err := c.Call(ctx, "history.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Clock string `json:"clock"`
+		Value string `json:"value"`
+	}
+	if err := unmarshal("history.get", resp, &raw); err != nil {
+		return nil, err
+	}
+
+	points := make([]HistoryPoint, 0, len(raw))
+	for _, r := range raw {
+		if p, ok := parsePoint(r.Clock, r.Value); ok {
+			points = append(points, p)
+		}
+	}
+	return points, nil
+}
+
+// GetTrends devolve as médias horárias do item no período via trend.get, em ordem cronológica
+func (c *Client) GetTrends(ctx context.Context, item Item, from, till time.Time) ([]HistoryPoint, error) {
+	if !item.Numeric() {
+		return nil, fmt.Errorf("item %s não é numérico", item.Key)
+	}
+
+	params := map[string]interface{}{
+		"output":    []string{"clock", "value_avg"},
+		"itemids":   []string{item.Itemid},
+		"time_from": from.Unix(),
+		"time_till": till.Unix(),
+	}
+
+	resp, err := c.Call(ctx, "trend.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Clock    string `json:"clock"`
+		ValueAvg string `json:"value_avg"`
+	}
+	if err := unmarshal("trend.get", resp, &raw); err != nil {
+		return nil, err
+	}
+
+	points := make([]HistoryPoint, 0, len(raw))
+	for _, r := range raw {
+		if p, ok := parsePoint(r.Clock, r.ValueAvg); ok {
+			points = append(points, p)
+		}
+	}
+
+	// trend.get não aceita sortfield "clock"
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Clock.Before(points[j].Clock)
+	})
+	return points, nil
+}
+
+func parsePoint(clock, value string) (HistoryPoint, bool) {
+	sec, err := strconv.ParseInt(clock, 10, 64)
+	if err != nil {
+		return HistoryPoint{}, false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return HistoryPoint{}, false
+	}
+	return HistoryPoint{Clock: time.Unix(sec, 0), Value: v}, true
+}
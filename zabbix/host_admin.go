@@ -0,0 +1,202 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+)
+
+// Tipos de interface de host
+const (
+	InterfaceAgent = 1
+	InterfaceSNMP  = 2
+)
+
+// NewHost descreve um host a ser criado com uma interface principal
+type NewHost struct {
+	Host          string
+	IP            string
+	InterfaceType int
+	Port          string
+	GroupIDs      []string
+	TemplateIDs   []string
+}
+
+// SetHostStatus ativa ou desativa o monitoramento do host via host.update
+func (c *Client) SetHostStatus(ctx context.Context, hostID string, enabled bool) error {
+	status := "1"
+	if enabled {
+		status = "0"
+	}
+
+	params := map[string]interface{}{
+		"hostid": hostID,
+		"status": status,
+	}
+
+	if _, err := c.Call(ctx, "host.update", params); err != nil {
+		return err
+	}
+	c.invalidateInventory()
+	return nil
+}
+
+// CreateHost cria o host com interface, grupos e templates e devolve o seu ID
+func (c *Client) CreateHost(ctx context.Context, h NewHost) (string, error) {
+	resp, err := c.Call(ctx, "host.create", hostParams(h))
+	if err != nil {
+		return "", err
+	}
+	c.invalidateInventory()
+
+	var result struct {
+		Hostids []string `json:"hostids"`
+	}
+	if err := unmarshal("host.create", resp, &result); err != nil {
+		return "", err
+	}
+	if len(result.Hostids) == 0 {
+		return "", &DecodeError{Method: "host.create", Err: errEmptyResult}
+	}
+	return result.Hostids[0], nil
+}
+
+// hostParams monta os parâmetros de host.create a partir de NewHost
+func hostParams(h NewHost) map[string]interface{} {
+	groups := make([]map[string]string, 0, len(h.GroupIDs))
+	for _, id := range h.GroupIDs {
+		groups = append(groups, map[string]string{"groupid": id})
+	}
+	templates := make([]map[string]string, 0, len(h.TemplateIDs))
+	for _, id := range h.TemplateIDs {
+		templates = append(templates, map[string]string{"templateid": id})
+	}
+
+	return map[string]interface{}{
+		"host":       h.Host,
+		"interfaces": []map[string]interface{}{interfaceParams(h)},
+		"groups":     groups,
+		"templates":  templates,
+	}
+}
+
+// interfaceParams monta a interface principal do host
+func interfaceParams(h NewHost) map[string]interface{} {
+	iface := map[string]interface{}{
+		"type":  h.InterfaceType,
+		"main":  1,
+		"useip": 1,
+		"ip":    h.IP,
+		"dns":   "",
+		"port":  h.Port,
+	}
+	// Interfaces SNMP exigem os detalhes da versão desde o Zabbix 5.0
+	if h.InterfaceType == InterfaceSNMP {
+		iface["details"] = map[string]interface{}{
+			"version":   2,
+			"bulk":      1,
+			"community": "{$SNMP_COMMUNITY}",
+		}
+	}
+	return iface
+}
+
+// DefaultPort devolve a porta padrão do tipo de interface
+func DefaultPort(interfaceType int) string {
+	if interfaceType == InterfaceSNMP {
+		return "161"
+	}
+	return "10050"
+}
+
+// TemplateID busca o template pelo nome técnico ou visível. ok é falso se não existir.
+func (c *Client) TemplateID(ctx context.Context, name string) (id string, ok bool, err error) {
+	for _, field := range []string{"host", "name"} {
+		params := map[string]interface{}{
+			"output": []string{"templateid"},
+			"filter": map[string]interface{}{field: []string{name}},
+		}
+
+		resp, err := c.Call(ctx, "template.get", params)
+		if err != nil {
+			return "", false, err
+		}
+
+		var templates []Template
+		if err := unmarshal("template.get", resp, &templates); err != nil {
+			return "", false, err
+		}
+		if len(templates) > 0 {
+			return templates[0].Templateid, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// HostsByIP devolve os nomes dos hosts que possuem alguma interface com o IP informado
+func (c *Client) HostsByIP(ctx context.Context, ip string) ([]string, error) {
+	params := map[string]interface{}{
+		"output":      []string{"interfaceid", "hostid"},
+		"filter":      map[string]interface{}{"ip": []string{ip}},
+		"selectHosts": []string{"host"},
+	}
+
+	resp, err := c.Call(ctx, "hostinterface.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var interfaces []struct {
+		Hosts []struct {
+			Host string `json:"host"`
+		} `json:"hosts"`
+	}
+	if err := unmarshal("hostinterface.get", resp, &interfaces); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, i := range interfaces {
+		for _, h := range i.Hosts {
+			if !seen[h.Host] {
+				seen[h.Host] = true
+				names = append(names, h.Host)
+			}
+		}
+	}
+	return names, nil
+}
+
+// ValidateNewHost confere se o nome e o IP ainda não estão em uso e se o grupo e o
+// template existem, preenchendo GroupIDs e TemplateIDs a partir dos nomes.
+func (c *Client) ValidateNewHost(ctx context.Context, h *NewHost, groupName, templateName string) error {
+	if _, exists, err := c.fetchHostByName(ctx, h.Host); err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("já existe um host com o nome %s", h.Host)
+	}
+
+	if used, err := c.HostsByIP(ctx, h.IP); err != nil {
+		return err
+	} else if len(used) > 0 {
+		return fmt.Errorf("o IP %s já está em uso por %v", h.IP, used)
+	}
+
+	groupID, err := c.GroupID(ctx, groupName)
+	if err != nil {
+		return err
+	}
+	h.GroupIDs = []string{groupID}
+
+	if templateName != "" {
+		templateID, ok, err := c.TemplateID(ctx, templateName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("template %q não encontrado no Zabbix", templateName)
+		}
+		h.TemplateIDs = []string{templateID}
+	}
+	return nil
+}
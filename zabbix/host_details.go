@@ -0,0 +1,250 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Interface é uma interface de host do Zabbix
+type Interface struct {
+	Interfaceid string `json:"interfaceid"`
+	Type        string `json:"type"` /* 1 - Agente 2 - SNMP 3 - IPMI 4 - JMX */
+	Main        string `json:"main"`
+	UseIP       string `json:"useip"`
+	IP          string `json:"ip"`
+	DNS         string `json:"dns"`
+	Port        string `json:"port"`
+	Available   string `json:"available"` /* 0 - Desconhecido 1 - Disponível 2 - Indisponível */
+}
+
+// Address devolve o IP ou o DNS usado pela interface
+func (i Interface) Address() string {
+	if i.UseIP == "0" && i.DNS != "" {
+		return i.DNS
+	}
+	return i.IP
+}
+
+// Tag é uma tag de host
+type Tag struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// Template é um template vinculado ao host
+type Template struct {
+	Templateid string `json:"templateid"`
+	Name       string `json:"name"`
+}
+
+// HostDetails reúne o cadastro completo de um host
+type HostDetails struct {
+	Hostid            string
+	Host              string
+	Name              string
+	Status            string
+	MaintenanceStatus string
+	Groups            []HostGroup
+	Templates         []Template
+	Interfaces        []Interface
+	Inventory         map[string]string
+	Tags              []Tag
+}
+
+// HostSummary é o resultado de uma busca de hosts por nome ou IP
+type HostSummary struct {
+	Hostid     string      `json:"hostid"`
+	Host       string      `json:"host"`
+	Name       string      `json:"name"`
+	Status     string      `json:"status"`
+	Interfaces []Interface `json:"interfaces"`
+}
+
+// GetHostDetails busca grupos, templates, interfaces, inventário e tags do host
+func (c *Client) GetHostDetails(ctx context.Context, hostID string) (HostDetails, bool, error) {
+	selectGroups, _, err := c.groupsSelector(ctx)
+	if err != nil {
+		return HostDetails{}, false, err
+	}
+
+	params := map[string]interface{}{
+		"output":                []string{"hostid", "host", "name", "status", "maintenance_status"},
+		"hostids":               []string{hostID},
+		selectGroups:            []string{"groupid", "name"},
+		"selectParentTemplates": []string{"templateid", "name"},
+		"selectInterfaces":      "extend",
+		"selectInventory":       "extend",
+		"selectTags":            "extend",
+	}
+
+	resp, err := c.Call(ctx, "host.get", params)
+	if err != nil {
+		return HostDetails{}, false, err
+	}
+
+	var raw []struct {
+		hostWithGroups
+		Name            string          `json:"name"`
+		ParentTemplates []Template      `json:"parentTemplates"`
+		Interfaces      []Interface     `json:"interfaces"`
+		Inventory       json.RawMessage `json:"inventory"`
+		Tags            []Tag           `json:"tags"`
+	}
+	if err := unmarshal("host.get", resp, &raw); err != nil {
+		return HostDetails{}, false, err
+	}
+	if len(raw) == 0 {
+		return HostDetails{}, false, nil
+	}
+
+	r := raw[0]
+	details := HostDetails{
+		Hostid:            r.Hostid,
+		Host:              r.Host,
+		Name:              r.Name,
+		Status:            r.Status,
+		MaintenanceStatus: r.MaintenanceStatus,
+		Groups:            r.groups(),
+		Templates:         r.ParentTemplates,
+		Interfaces:        r.Interfaces,
+		Tags:              r.Tags,
+		Inventory:         make(map[string]string),
+	}
+
+	// Com o inventário desativado o Zabbix devolve uma lista vazia em vez de objeto
+	var inventory map[string]interface{}
+	if json.Unmarshal(r.Inventory, &inventory) == nil {
+		for field, value := range inventory {
+			if s, ok := value.(string); ok && s != "" && field != "hostid" && field != "inventory_mode" {
+				details.Inventory[field] = s
+			}
+		}
+	}
+
+	return details, true, nil
+}
+
+// SearchHosts procura hosts (ativos ou não) pelo nome técnico, nome visível, IP ou DNS.
+// O resultado vem ordenado do melhor para o pior casamento; nomes parecidos também
+// são aceitos, para tolerar erros de digitação.
+func (c *Client) SearchHosts(ctx context.Context, query string) ([]HostSummary, error) {
+	if cached, ok := c.inventory.snapshot(); ok {
+		hosts := make([]HostSummary, 0, len(cached))
+		for _, h := range cached {
+			hosts = append(hosts, h.toSummary())
+		}
+		return MatchHosts(hosts, query), nil
+	}
+
+	params := map[string]interface{}{
+		"output":           []string{"hostid", "host", "name", "status"},
+		"selectInterfaces": []string{"interfaceid", "ip", "dns", "useip", "type", "main", "port"},
+	}
+
+	resp, err := c.Call(ctx, "host.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []HostSummary
+	if err := unmarshal("host.get", resp, &hosts); err != nil {
+		return nil, err
+	}
+	return MatchHosts(hosts, query), nil
+}
+
+// MatchHosts filtra e ordena os hosts pela proximidade com a consulta. Um casamento
+// exato de nome ou IP é devolvido sozinho.
+func MatchHosts(hosts []HostSummary, query string) []HostSummary {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil
+	}
+
+	type scored struct {
+		host  HostSummary
+		score int
+	}
+	var matches []scored
+
+	for _, h := range hosts {
+		best := -1
+		candidates := []string{strings.ToLower(h.Host), strings.ToLower(h.Name)}
+		for _, i := range h.Interfaces {
+			candidates = append(candidates, strings.ToLower(i.IP), strings.ToLower(i.DNS))
+		}
+
+		for _, cand := range candidates {
+			if cand == "" {
+				continue
+			}
+			score := -1
+			switch {
+			case cand == q:
+				score = 0
+			case strings.HasPrefix(cand, q):
+				score = 1
+			case strings.Contains(cand, q):
+				score = 2
+			case len(q) >= 4 && levenshtein(cand, q) <= maxTypos(q):
+				score = 3
+			}
+			if score >= 0 && (best < 0 || score < best) {
+				best = score
+			}
+		}
+
+		if best >= 0 {
+			matches = append(matches, scored{host: h, score: best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].host.Host < matches[j].host.Host
+	})
+
+	var result []HostSummary
+	for _, m := range matches {
+		if m.score == 0 {
+			return []HostSummary{m.host}
+		}
+		result = append(result, m.host)
+	}
+	return result
+}
+
+// maxTypos define quantos erros de digitação são tolerados conforme o tamanho da consulta
+func maxTypos(q string) int {
+	if len(q) >= 8 {
+		return 2
+	}
+	return 1
+}
+
+// levenshtein calcula a distância de edição entre a e b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,290 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ImportAction é o que a importação fará com uma linha da planilha
+type ImportAction int
+
+const (
+	ImportInvalid ImportAction = iota
+	ImportCreate
+	ImportUpdate
+	ImportUnchanged
+)
+
+// HostImportRow é uma linha da planilha de importação de hosts
+type HostImportRow struct {
+	Line     int
+	Host     string
+	IP       string
+	Type     string /* agente ou snmp; vazio assume agente */
+	Group    string
+	Template string
+}
+
+// HostImportPlan é o resultado da validação de uma linha, antes de alterar o Zabbix
+type HostImportPlan struct {
+	Row     HostImportRow
+	Action  ImportAction
+	Changes []string
+	Err     error
+
+	host           NewHost
+	hostID         string
+	interfaceID    string
+	newInterface   bool
+	addGroupIDs    []string
+	addTemplateIDs []string
+}
+
+// ParseInterfaceType converte "agente" ou "snmp" no tipo de interface do Zabbix
+func ParseInterfaceType(value string) (int, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "agente", "agent", "zabbix":
+		return InterfaceAgent, true
+	case "snmp":
+		return InterfaceSNMP, true
+	}
+	return 0, false
+}
+
+// PlanHostImport valida as linhas e compara cada uma com o cadastro atual do Zabbix.
+// Problemas de uma linha ficam em Err; o erro retornado indica falha na comunicação.
+func (c *Client) PlanHostImport(ctx context.Context, rows []HostImportRow) ([]HostImportPlan, error) {
+	var groupNames []string
+	for _, r := range rows {
+		if r.Group != "" {
+			groupNames = append(groupNames, r.Group)
+		}
+	}
+	groupIDs, err := c.GroupIDs(ctx, groupNames)
+	if err != nil {
+		return nil, err
+	}
+
+	templateIDs := make(map[string]string)
+	seenHosts := make(map[string]int)
+	seenIPs := make(map[string]int)
+
+	plans := make([]HostImportPlan, 0, len(rows))
+	for _, r := range rows {
+		plan := HostImportPlan{Row: r}
+
+		invalid := func(format string, args ...interface{}) {
+			plan.Action = ImportInvalid
+			plan.Err = fmt.Errorf(format, args...)
+		}
+
+		interfaceType, validType := ParseInterfaceType(r.Type)
+		key := strings.ToLower(r.Host)
+
+		switch {
+		case r.Host == "" || strings.ContainsAny(r.Host, " \t"):
+			invalid("nome de host inválido: %q", r.Host)
+		case net.ParseIP(r.IP) == nil:
+			invalid("IP inválido: %q", r.IP)
+		case !validType:
+			invalid("tipo de interface inválido: %q (use agente ou snmp)", r.Type)
+		case r.Group == "":
+			invalid("grupo não informado")
+		case groupIDs[r.Group] == "":
+			invalid("grupo %q não encontrado no Zabbix", r.Group)
+		case seenHosts[key] != 0:
+			invalid("host repetido na linha %d", seenHosts[key])
+		case seenIPs[r.IP] != 0:
+			invalid("IP repetido na linha %d", seenIPs[r.IP])
+		}
+		if plan.Err != nil {
+			plans = append(plans, plan)
+			continue
+		}
+		seenHosts[key] = r.Line
+		seenIPs[r.IP] = r.Line
+
+		plan.host = NewHost{
+			Host:          r.Host,
+			IP:            r.IP,
+			InterfaceType: interfaceType,
+			Port:          DefaultPort(interfaceType),
+			GroupIDs:      []string{groupIDs[r.Group]},
+		}
+
+		if r.Template != "" {
+			id, ok := templateIDs[r.Template]
+			if !ok {
+				var found bool
+				if id, found, err = c.TemplateID(ctx, r.Template); err != nil {
+					return nil, err
+				}
+				if !found {
+					id = ""
+				}
+				templateIDs[r.Template] = id
+			}
+			if id == "" {
+				invalid("template %q não encontrado no Zabbix", r.Template)
+				plans = append(plans, plan)
+				continue
+			}
+			plan.host.TemplateIDs = []string{id}
+		}
+
+		if err := c.planHost(ctx, &plan); err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// planHost define se a linha já validada cria um host novo ou atualiza um existente
+func (c *Client) planHost(ctx context.Context, plan *HostImportPlan) error {
+	h := plan.host
+
+	existing, exists, err := c.fetchHostByName(ctx, h.Host)
+	if err != nil {
+		return err
+	}
+
+	usedBy, err := c.HostsByIP(ctx, h.IP)
+	if err != nil {
+		return err
+	}
+	for _, name := range usedBy {
+		if !strings.EqualFold(name, h.Host) {
+			plan.Action = ImportInvalid
+			plan.Err = fmt.Errorf("o IP %s já está em uso por %s", h.IP, name)
+			return nil
+		}
+	}
+
+	if !exists {
+		plan.Action = ImportCreate
+		return nil
+	}
+
+	details, ok, err := c.GetHostDetails(ctx, existing.Hostid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		plan.Action = ImportInvalid
+		plan.Err = fmt.Errorf("host %s não encontrado", h.Host)
+		return nil
+	}
+	plan.hostID = details.Hostid
+
+	// Compara o IP com a interface principal do mesmo tipo
+	kind := fmt.Sprint(h.InterfaceType)
+	currentIP := ""
+	for _, i := range details.Interfaces {
+		if i.Type == kind && i.Main == "1" {
+			plan.interfaceID = i.Interfaceid
+			currentIP = i.IP
+		}
+	}
+	if currentIP != h.IP {
+		if currentIP == "" {
+			plan.newInterface = true
+			plan.Changes = append(plan.Changes, fmt.Sprintf("nova interface %s", h.IP))
+		} else {
+			plan.Changes = append(plan.Changes, fmt.Sprintf("IP %s → %s", currentIP, h.IP))
+		}
+	} else {
+		plan.interfaceID = ""
+	}
+
+	// Grupos e templates são apenas acrescentados; vínculos atuais são mantidos
+	hasGroup := make(map[string]bool)
+	for _, g := range details.Groups {
+		hasGroup[g.Groupid] = true
+	}
+	for _, id := range h.GroupIDs {
+		if !hasGroup[id] {
+			plan.addGroupIDs = append(plan.addGroupIDs, id)
+			plan.Changes = append(plan.Changes, fmt.Sprintf("+ grupo %s", plan.Row.Group))
+		}
+	}
+
+	hasTemplate := make(map[string]bool)
+	for _, t := range details.Templates {
+		hasTemplate[t.Templateid] = true
+	}
+	for _, id := range h.TemplateIDs {
+		if !hasTemplate[id] {
+			plan.addTemplateIDs = append(plan.addTemplateIDs, id)
+			plan.Changes = append(plan.Changes, fmt.Sprintf("+ template %s", plan.Row.Template))
+		}
+	}
+
+	if len(plan.Changes) == 0 {
+		plan.Action = ImportUnchanged
+	} else {
+		plan.Action = ImportUpdate
+	}
+	return nil
+}
+
+// ApplyHostImport cria ou atualiza os hosts planejados. O resultado de cada linha
+// fica no índice correspondente de plans; linhas inválidas ou sem mudanças são ignoradas.
+func (c *Client) ApplyHostImport(ctx context.Context, plans []HostImportPlan) []error {
+	results := make([]error, len(plans))
+	for i, p := range plans {
+		switch p.Action {
+		case ImportCreate:
+			_, results[i] = c.CreateHost(ctx, p.host)
+		case ImportUpdate:
+			results[i] = c.updateImportedHost(ctx, p)
+		}
+	}
+	c.invalidateInventory()
+	return results
+}
+
+func (c *Client) updateImportedHost(ctx context.Context, p HostImportPlan) error {
+	if p.interfaceID != "" {
+		params := map[string]interface{}{
+			"interfaceid": p.interfaceID,
+			"ip":          p.host.IP,
+		}
+		if _, err := c.Call(ctx, "hostinterface.update", params); err != nil {
+			return err
+		}
+	} else if p.newInterface {
+		iface := interfaceParams(p.host)
+		iface["hostid"] = p.hostID
+		if _, err := c.Call(ctx, "hostinterface.create", iface); err != nil {
+			return err
+		}
+	}
+
+	if len(p.addGroupIDs) == 0 && len(p.addTemplateIDs) == 0 {
+		return nil
+	}
+
+	params := map[string]interface{}{
+		"hosts": []map[string]string{{"hostid": p.hostID}},
+	}
+	if len(p.addGroupIDs) > 0 {
+		groups := make([]map[string]string, 0, len(p.addGroupIDs))
+		for _, id := range p.addGroupIDs {
+			groups = append(groups, map[string]string{"groupid": id})
+		}
+		params["groups"] = groups
+	}
+	if len(p.addTemplateIDs) > 0 {
+		templates := make([]map[string]string, 0, len(p.addTemplateIDs))
+		for _, id := range p.addTemplateIDs {
+			templates = append(templates, map[string]string{"templateid": id})
+		}
+		params["templates"] = templates
+	}
+
+	_, err := c.Call(ctx, "host.massadd", params)
+	return err
+}
@@ -1,17 +1,148 @@
 package zabbix
 
-import "encoding/json"
+import "context"
 
 type Host struct {
-	Hostid    string `json:"hostid"`
-	Host      string `json:"host"`
-	Status    string `json:"status"` /* 0 - Ativo 1 - Inativo */
-	Lastvalue string `json:"lastvalue"`
-	Prevvalue string `json:"prevvalue"`
-	Error     bool
+	Hostid            string `json:"hostid"`
+	Host              string `json:"host"`
+	Status            string `json:"status"`             /* 0 - Ativo 1 - Inativo */
+	MaintenanceStatus string `json:"maintenance_status"` /* 1 - Em manutenção */
+	Lastvalue         string `json:"lastvalue"`
+	Prevvalue         string `json:"prevvalue"`
+	Error             bool
 }
 
-func (c *Client) GetHosts() ([]Host, error) {
+// InMaintenance indica se o host está em uma manutenção ativa
+func (h Host) InMaintenance() bool {
+	return h.MaintenanceStatus == "1"
+}
+
+// GetHostByName busca um host pelo nome técnico ou pelo nome visível. ok é falso
+// quando nenhum host corresponde ao nome.
+func (c *Client) GetHostByName(ctx context.Context, name string) (host Host, ok bool, err error) {
+	if hosts, cached := c.inventory.snapshot(); cached {
+		h, ok := cachedHostByName(hosts, name)
+		return h.toHost(), ok, nil
+	}
+	return c.fetchHostByName(ctx, name)
+}
+
+// GetHostGroups busca os grupos de um host pelo nome técnico ou pelo nome visível.
+// ok é falso quando nenhum host corresponde ao nome.
+func (c *Client) GetHostGroups(ctx context.Context, name string) (groups []HostGroup, ok bool, err error) {
+	if hosts, cached := c.inventory.snapshot(); cached {
+		h, ok := cachedHostByName(hosts, name)
+		return h.Groups, ok, nil
+	}
+
+	host, ok, err := c.fetchHostByName(ctx, name)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	details, ok, err := c.GetHostDetails(ctx, host.Hostid)
+	return details.Groups, ok, err
+}
+
+// CachedHostGroups busca os grupos de um host só no inventário, sem consultar a API.
+// ok é falso quando o inventário não está válido ou nenhum host corresponde ao nome.
+func (c *Client) CachedHostGroups(name string) (groups []HostGroup, ok bool) {
+	hosts, cached := c.inventory.snapshot()
+	if !cached {
+		return nil, false
+	}
+	h, ok := cachedHostByName(hosts, name)
+	return h.Groups, ok
+}
+
+// GetHostTagValues devolve o valor da tag em cada host ativo que a possui, pelo nome do host
+func (c *Client) GetHostTagValues(ctx context.Context, tag string) (map[string]string, error) {
+	if hosts, cached := c.inventory.snapshot(); cached {
+		values := make(map[string]string)
+		for _, h := range hosts {
+			if h.Status != "0" {
+				continue
+			}
+			if value, ok := tagValue(h.Tags, tag); ok {
+				values[h.Host] = value
+			}
+		}
+		return values, nil
+	}
+
+	params := map[string]interface{}{
+		"output":     []string{"hostid", "host"},
+		"filter":     map[string]string{"status": "0"},
+		"selectTags": "extend",
+	}
+
+	resp, err := c.Call(ctx, "host.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []struct {
+		Host string `json:"host"`
+		Tags []Tag  `json:"tags"`
+	}
+	if err := unmarshal("host.get", resp, &hosts); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, h := range hosts {
+		if value, ok := tagValue(h.Tags, tag); ok {
+			values[h.Host] = value
+		}
+	}
+	return values, nil
+}
+
+// tagValue devolve o valor da primeira tag com o nome informado
+func tagValue(tags []Tag, name string) (string, bool) {
+	for _, t := range tags {
+		if t.Tag == name {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
+// fetchHostByName consulta o host direto na API, sem o inventário. Usado nas
+// validações antes de criar hosts, que não podem depender de dados antigos.
+func (c *Client) fetchHostByName(ctx context.Context, name string) (host Host, ok bool, err error) {
+	for _, field := range []string{"host", "name"} {
+		params := map[string]interface{}{
+			"output": []string{"hostid", "host", "status", "maintenance_status"},
+			"filter": map[string]interface{}{field: []string{name}},
+		}
+
+		resp, err := c.Call(ctx, "host.get", params)
+		if err != nil {
+			return Host{}, false, err
+		}
+
+		var hosts []Host
+		if err := unmarshal("host.get", resp, &hosts); err != nil {
+			return Host{}, false, err
+		}
+		if len(hosts) > 0 {
+			return hosts[0], true, nil
+		}
+	}
+	return Host{}, false, nil
+}
+
+func (c *Client) GetHosts(ctx context.Context) ([]Host, error) {
+	if cached, ok := c.inventory.snapshot(); ok {
+		var hosts []Host
+		for _, h := range cached {
+			if h.Status == "0" {
+				hosts = append(hosts, h.toHost())
+			}
+		}
+		return hosts, nil
+	}
+
 	params := map[string]interface{}{
 		"output": "extend",
 		"filter": map[string]string{
@@ -19,53 +150,131 @@ func (c *Client) GetHosts() ([]Host, error) {
 		},
 	}
 
-	resp, err := c.Call("host.get", params)
+	resp, err := c.Call(ctx, "host.get", params)
 	if err != nil {
 		return nil, err
 	}
 
 	var hosts []Host
-	json.Unmarshal(resp, &hosts)
+	if err := unmarshal("host.get", resp, &hosts); err != nil {
+		return nil, err
+	}
 	return hosts, nil
 }
 
-// GetHostsExcludingGroups retorna hosts ativos (status=0) incluindo informações de grupo
-// e exclui quaisquer hosts que pertençam a grupos cujo nome esteja na lista excludeNames.
-func (c *Client) GetHostsExcludingGroups(excludeNames []string) ([]Host, error) {
+// HostsInMaintenance devolve os IDs dos hosts em manutenção ativa. Consulta a API
+// diretamente: no inventário, o maintenance_status pode estar até dois intervalos
+// atrasado, e o monitor avisaria de hosts que acabaram de entrar em manutenção.
+func (c *Client) HostsInMaintenance(ctx context.Context) (map[string]bool, error) {
 	params := map[string]interface{}{
-		"output":       "extend",
-		"filter":       map[string]string{"status": "0"},
-		"selectGroups": "extend",
+		"output": []string{"hostid"},
+		"filter": map[string]string{"maintenance_status": "1"},
 	}
 
-	resp, err := c.Call("host.get", params)
+	resp, err := c.Call(ctx, "host.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	// Tipo auxiliar para leitura com grupos
-	var rawHosts []struct {
-		Hostid string `json:"hostid"`
-		Host   string `json:"host"`
-		Status string `json:"status"`
-		Groups []struct {
-			Name string `json:"name"`
-		} `json:"groups"`
+	var hosts []Host
+	if err := unmarshal("host.get", resp, &hosts); err != nil {
+		return nil, err
 	}
 
-	json.Unmarshal(resp, &rawHosts)
+	ids := make(map[string]bool, len(hosts))
+	for _, h := range hosts {
+		ids[h.Hostid] = true
+	}
+	return ids, nil
+}
+
+// GetHostsInGroup retorna os hosts ativos (status=0) do grupo com o nome informado
+func (c *Client) GetHostsInGroup(ctx context.Context, name string) ([]Host, error) {
+	groupID, err := c.GroupID(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := c.inventory.snapshot(); ok {
+		var hosts []Host
+		for _, h := range cached {
+			if h.Status == "0" && h.inGroup(map[string]bool{groupID: true}) {
+				hosts = append(hosts, h.toHost())
+			}
+		}
+		return hosts, nil
+	}
+
+	params := map[string]interface{}{
+		"output":   "extend",
+		"groupids": groupID,
+		"filter": map[string]string{
+			"status": "0",
+		},
+	}
+
+	resp, err := c.Call(ctx, "host.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []Host
+	if err := unmarshal("host.get", resp, &hosts); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
+
+// GetHostsExcludingGroups retorna hosts ativos (status=0) incluindo informações de grupo
+// e exclui quaisquer hosts que pertençam a grupos cujo nome esteja na lista excludeNames.
+func (c *Client) GetHostsExcludingGroups(ctx context.Context, excludeNames []string) ([]Host, error) {
+	excludeIDs, err := c.GroupIDs(ctx, excludeNames)
+	if err != nil {
+		return nil, err
+	}
 
 	// Cria mapa de exclusão para checagem rápida
 	excludeMap := make(map[string]bool)
-	for _, n := range excludeNames {
-		excludeMap[n] = true
+	for _, id := range excludeIDs {
+		excludeMap[id] = true
+	}
+
+	if cached, ok := c.inventory.snapshot(); ok {
+		var hosts []Host
+		for _, h := range cached {
+			if h.Status == "0" && !h.inGroup(excludeMap) {
+				hosts = append(hosts, h.toHost())
+			}
+		}
+		return hosts, nil
+	}
+
+	selectParam, _, err := c.groupsSelector(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"output":    "extend",
+		"filter":    map[string]string{"status": "0"},
+		selectParam: []string{"groupid", "name"},
+	}
+
+	resp, err := c.Call(ctx, "host.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawHosts []hostWithGroups
+	if err := unmarshal("host.get", resp, &rawHosts); err != nil {
+		return nil, err
 	}
 
 	var hosts []Host
 	for _, rh := range rawHosts {
 		skip := false
-		for _, g := range rh.Groups {
-			if excludeMap[g.Name] {
+		for _, g := range rh.groups() {
+			if excludeMap[g.Groupid] {
 				skip = true
 				break
 			}
@@ -74,8 +283,34 @@ func (c *Client) GetHostsExcludingGroups(excludeNames []string) ([]Host, error)
 			continue
 		}
 
-		hosts = append(hosts, Host{Hostid: rh.Hostid, Host: rh.Host, Status: rh.Status})
+		hosts = append(hosts, Host{Hostid: rh.Hostid, Host: rh.Host, Status: rh.Status, MaintenanceStatus: rh.MaintenanceStatus})
 	}
 
 	return hosts, nil
 }
+
+// HostGroup é um grupo de hosts do Zabbix
+type HostGroup struct {
+	Groupid string `json:"groupid"`
+	Name    string `json:"name"`
+}
+
+// hostWithGroups lê a resposta de host.get tanto com selectGroups quanto com selectHostGroups
+type hostWithGroups struct {
+	Hostid            string      `json:"hostid"`
+	Host              string      `json:"host"`
+	Name              string      `json:"name"`
+	Status            string      `json:"status"`
+	MaintenanceStatus string      `json:"maintenance_status"`
+	Groups            []HostGroup `json:"groups"`
+	HostGroups        []HostGroup `json:"hostgroups"`
+	Interfaces        []Interface `json:"interfaces"`
+	Tags              []Tag       `json:"tags"`
+}
+
+func (h hostWithGroups) groups() []HostGroup {
+	if len(h.HostGroups) > 0 {
+		return h.HostGroups
+	}
+	return h.Groups
+}
@@ -0,0 +1,30 @@
+package zabbix_test
+
+import (
+	"LapaTelegramBot/zabbix"
+	"LapaTelegramBot/zabbix/zabbixtest"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetHostTagValuesUsesInventory(t *testing.T) {
+	s := zabbixtest.NewServer()
+	defer s.Close()
+	s.AddHost(zabbixtest.Host{Host: "SRV-FILIAL-SP", Tags: []zabbix.Tag{{Tag: "parent", Value: "RT-FILIAL-SP"}}})
+	s.AddHost(zabbixtest.Host{Host: "RT-FILIAL-SP", Tags: []zabbix.Tag{{Tag: "site", Value: "SP"}}})
+	s.AddHost(zabbixtest.Host{Host: "SRV-OLD", Disabled: true, Tags: []zabbix.Tag{{Tag: "parent", Value: "RT-FILIAL-SP"}}})
+	c := inventoryClient(t, s)
+	calls := s.Calls("host.get")
+
+	values, err := c.GetHostTagValues(context.Background(), "parent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"SRV-FILIAL-SP": "RT-FILIAL-SP"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("valores = %v, esperado %v", values, want)
+	}
+	if n := s.Calls("host.get"); n != calls {
+		t.Errorf("host.get chamado %d vezes, esperado nenhuma com o inventário válido", n-calls)
+	}
+}
@@ -0,0 +1,273 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// InventoryHost é um host guardado no inventário, com grupos, interfaces e tags
+type InventoryHost struct {
+	Hostid            string
+	Host              string
+	Name              string
+	Status            string
+	MaintenanceStatus string
+	Groups            []HostGroup
+	Interfaces        []Interface
+	Tags              []Tag
+}
+
+// Inventory é o cache em memória de hosts, grupos, interfaces e tags de um servidor.
+// As consultas por nome, IP e grupo usam o cache enquanto ele estiver válido;
+// caso contrário, vão direto à API.
+type Inventory struct {
+	// Interval é o intervalo entre as atualizações em segundo plano. Zero desativa o cache.
+	Interval time.Duration
+
+	mu        sync.RWMutex
+	hosts     []InventoryHost
+	groups    int
+	updatedAt time.Time
+	lastErr   error
+	stale     bool
+
+	// refreshMu evita duas atualizações simultâneas (automática e /zbx_refresh)
+	refreshMu sync.Mutex
+	wake      chan struct{}
+}
+
+func newInventory(interval time.Duration) *Inventory {
+	return &Inventory{Interval: interval, wake: make(chan struct{}, 1)}
+}
+
+// Enabled indica se o cache está ativo
+func (inv *Inventory) Enabled() bool {
+	return inv != nil && inv.Interval > 0
+}
+
+// UpdatedAt devolve o horário da última atualização bem-sucedida (zero se nunca atualizou)
+func (inv *Inventory) UpdatedAt() time.Time {
+	if inv == nil {
+		return time.Time{}
+	}
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	return inv.updatedAt
+}
+
+// Size devolve a quantidade de hosts e grupos em cache
+func (inv *Inventory) Size() (hosts, groups int) {
+	if inv == nil {
+		return 0, 0
+	}
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	return len(inv.hosts), inv.groups
+}
+
+// LastError devolve o erro da última atualização, ou nil se ela deu certo
+func (inv *Inventory) LastError() error {
+	if inv == nil {
+		return nil
+	}
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	return inv.lastErr
+}
+
+// snapshot devolve os hosts em cache se o inventário estiver válido. Dados com mais
+// de dois intervalos (atualizações falhando) ou invalidados por uma alteração são ignorados.
+func (inv *Inventory) snapshot() ([]InventoryHost, bool) {
+	if !inv.Enabled() {
+		return nil, false
+	}
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+
+	if inv.updatedAt.IsZero() || inv.stale || time.Since(inv.updatedAt) > 2*inv.Interval {
+		return nil, false
+	}
+	return inv.hosts, true
+}
+
+// InventoryAge devolve a idade dos dados em cache. ok é falso quando as consultas
+// não estão sendo atendidas pelo inventário.
+func (c *Client) InventoryAge() (age time.Duration, ok bool) {
+	if _, ok := c.inventory.snapshot(); !ok {
+		return 0, false
+	}
+	return time.Since(c.inventory.UpdatedAt()), true
+}
+
+// Inventory devolve o inventário do servidor
+func (c *Client) Inventory() *Inventory {
+	return c.inventory
+}
+
+// RefreshInventory recarrega hosts, grupos, interfaces e tags via host.get e hostgroup.get.
+// O mapa de grupos por nome também é substituído, refletindo grupos renomeados ou removidos.
+func (c *Client) RefreshInventory(ctx context.Context) error {
+	inv := c.inventory
+	if inv == nil {
+		return nil
+	}
+	inv.refreshMu.Lock()
+	defer inv.refreshMu.Unlock()
+
+	hosts, groups, err := c.fetchInventory(ctx)
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.lastErr = err
+	if err != nil {
+		return err
+	}
+	inv.hosts = hosts
+	inv.groups = len(groups)
+	inv.updatedAt = time.Now()
+	inv.stale = false
+
+	c.mu.Lock()
+	c.groupIDs = groups
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Client) fetchInventory(ctx context.Context) ([]InventoryHost, map[string]string, error) {
+	selectParam, _, err := c.groupsSelector(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := map[string]interface{}{
+		"output":           []string{"hostid", "host", "name", "status", "maintenance_status"},
+		selectParam:        []string{"groupid", "name"},
+		"selectInterfaces": []string{"interfaceid", "ip", "dns", "useip", "type", "main", "port"},
+		"selectTags":       []string{"tag", "value"},
+	}
+
+	resp, err := c.Call(ctx, "host.get", params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var raw []hostWithGroups
+	if err := unmarshal("host.get", resp, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	hosts := make([]InventoryHost, 0, len(raw))
+	for _, r := range raw {
+		hosts = append(hosts, InventoryHost{
+			Hostid:            r.Hostid,
+			Host:              r.Host,
+			Name:              r.Name,
+			Status:            r.Status,
+			MaintenanceStatus: r.MaintenanceStatus,
+			Groups:            r.groups(),
+			Interfaces:        r.Interfaces,
+			Tags:              r.Tags,
+		})
+	}
+
+	resp, err = c.Call(ctx, "hostgroup.get", map[string]interface{}{
+		"output": []string{"groupid", "name"},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var list []HostGroup
+	if err := unmarshal("hostgroup.get", resp, &list); err != nil {
+		return nil, nil, err
+	}
+
+	groups := make(map[string]string, len(list))
+	for _, g := range list {
+		groups[g.Name] = g.Groupid
+	}
+	return hosts, groups, nil
+}
+
+// RunInventory atualiza o inventário imediatamente e depois a cada Interval, até o
+// contexto ser cancelado. Não faz nada se o cache estiver desativado.
+func (c *Client) RunInventory(ctx context.Context) {
+	inv := c.inventory
+	if !inv.Enabled() {
+		return
+	}
+
+	refresh := func() {
+		if err := c.RefreshInventory(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("⚠️  Erro ao atualizar inventário do Zabbix %s: %v", c.Name, err)
+		}
+	}
+	refresh()
+
+	ticker := time.NewTicker(inv.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		case <-inv.wake:
+			refresh()
+		}
+	}
+}
+
+// invalidateInventory descarta o cache após uma alteração feita pelo bot e pede
+// uma nova atualização; até lá, as consultas vão direto à API.
+func (c *Client) invalidateInventory() {
+	inv := c.inventory
+	if !inv.Enabled() {
+		return
+	}
+
+	inv.mu.Lock()
+	inv.stale = true
+	inv.mu.Unlock()
+
+	select {
+	case inv.wake <- struct{}{}:
+	default:
+	}
+}
+
+// cachedHostByName procura no inventário pelo nome técnico e depois pelo nome visível
+func cachedHostByName(hosts []InventoryHost, name string) (InventoryHost, bool) {
+	for _, h := range hosts {
+		if h.Host == name {
+			return h, true
+		}
+	}
+	for _, h := range hosts {
+		if h.Name == name {
+			return h, true
+		}
+	}
+	return InventoryHost{}, false
+}
+
+// inGroup indica se o host pertence a algum dos grupos informados
+func (h InventoryHost) inGroup(groupIDs map[string]bool) bool {
+	for _, g := range h.Groups {
+		if groupIDs[g.Groupid] {
+			return true
+		}
+	}
+	return false
+}
+
+func (h InventoryHost) toHost() Host {
+	return Host{Hostid: h.Hostid, Host: h.Host, Status: h.Status, MaintenanceStatus: h.MaintenanceStatus}
+}
+
+func (h InventoryHost) toSummary() HostSummary {
+	return HostSummary{Hostid: h.Hostid, Host: h.Host, Name: h.Name, Status: h.Status, Interfaces: h.Interfaces}
+}
@@ -1,31 +1,48 @@
 package zabbix
 
-import "encoding/json"
+import "context"
 
 type HostResponse struct {
-	HostID     string `json:"hostid"`
-	Host       string `json:"host"`
-	Interfaces []struct {
-		InterfaceID string `json:"interfaceid"`
-		IP          string `json:"ip"`
-	} `json:"interfaces"`
+	HostID     string        `json:"hostid"`
+	Host       string        `json:"host"`
+	Interfaces []InterfaceIP `json:"interfaces"`
 }
 
-func (c *Client) ListIps() ([]HostResponse, error) {
+// InterfaceIP é o IP de uma interface listado pelo /listip
+type InterfaceIP struct {
+	InterfaceID string `json:"interfaceid"`
+	IP          string `json:"ip"`
+}
+
+func (c *Client) ListIps(ctx context.Context) ([]HostResponse, error) {
+	if cached, ok := c.inventory.snapshot(); ok {
+		var hosts []HostResponse
+		for _, h := range cached {
+			if h.Status != "0" {
+				continue
+			}
+			r := HostResponse{HostID: h.Hostid, Host: h.Host}
+			for _, i := range h.Interfaces {
+				r.Interfaces = append(r.Interfaces, InterfaceIP{InterfaceID: i.Interfaceid, IP: i.IP})
+			}
+			hosts = append(hosts, r)
+		}
+		return hosts, nil
+	}
+
 	params := map[string]interface{}{
 		"output":           []string{"hostid", "host"},
 		"filter":           map[string]string{"status": "0"},
 		"selectInterfaces": []string{"interfaceid", "ip"},
 	}
 
-	resp, err := c.Call("host.get", params)
+	resp, err := c.Call(ctx, "host.get", params)
 	if err != nil {
 		return nil, err
 	}
 
 	var hosts []HostResponse
-	err = json.Unmarshal(resp, &hosts)
-	if err != nil {
+	if err := unmarshal("host.get", resp, &hosts); err != nil {
 		return nil, err
 	}
 	return hosts, nil
@@ -0,0 +1,132 @@
+package zabbix
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// itemsChunkSize limita quantos hosts são consultados em cada chamada item.get
+const itemsChunkSize = 100
+
+// itemsWorkers limita quantas chamadas item.get rodam ao mesmo tempo quando há mais de um lote
+const itemsWorkers = 4
+
+// Tipos de informação de item (value_type)
+const (
+	ValueFloat    = "0"
+	ValueChar     = "1"
+	ValueLog      = "2"
+	ValueUnsigned = "3"
+	ValueText     = "4"
+)
+
+// Item é um item do Zabbix com o último valor coletado
+type Item struct {
+	Itemid    string `json:"itemid"`
+	Hostid    string `json:"hostid"`
+	Name      string `json:"name"`
+	Key       string `json:"key_"`
+	ValueType string `json:"value_type"`
+	Units     string `json:"units"`
+	Lastvalue string `json:"lastvalue"`
+	Prevvalue string `json:"prevvalue"`
+	Lastclock string `json:"lastclock"`
+}
+
+// Numeric indica se o item guarda valores numéricos (float ou inteiro sem sinal)
+func (i Item) Numeric() bool {
+	return i.ValueType == ValueFloat || i.ValueType == ValueUnsigned
+}
+
+// LastCheck devolve o horário da última coleta; zero se o item nunca foi coletado
+func (i Item) LastCheck() time.Time {
+	clock, _ := strconv.ParseInt(i.Lastclock, 10, 64)
+	if clock == 0 {
+		return time.Time{}
+	}
+	return time.Unix(clock, 0)
+}
+
+// GetItems busca os itens dos hosts informados cuja key contém keySearch. A busca
+// aceita "*" como curinga, ex: "vfs.fs.size[*,pused]".
+func (c *Client) GetItems(ctx context.Context, hostIDs []string, keySearch string) ([]Item, error) {
+	params := map[string]interface{}{
+		"output":    []string{"itemid", "hostid", "name", "key_", "value_type", "units", "lastvalue", "prevvalue", "lastclock"},
+		"hostids":   hostIDs,
+		"sortfield": "name",
+	}
+	if keySearch != "" {
+		params["search"] = map[string]string{"key_": keySearch}
+		params["searchWildcardsEnabled"] = strings.Contains(keySearch, "*")
+	}
+
+	resp, err := c.Call(ctx, "item.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	if err := unmarshal("item.get", resp, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetItemsByHost busca, em lotes de item.get, os itens cuja key contém keySearch para
+// todos os hostIDs informados. Retorna os itens agrupados por hostid e, para cada
+// host de um lote que falhou, o erro correspondente.
+func (c *Client) GetItemsByHost(ctx context.Context, hostIDs []string, keySearch string) (map[string][]Item, map[string]error) {
+	items := make(map[string][]Item)
+	failed := make(map[string]error)
+
+	var chunks [][]string
+	for start := 0; start < len(hostIDs); start += itemsChunkSize {
+		end := min(start+itemsChunkSize, len(hostIDs))
+		chunks = append(chunks, hostIDs[start:end])
+	}
+
+	var mu sync.Mutex
+	fetch := func(chunk []string) {
+		result, err := c.GetItems(ctx, chunk, keySearch)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			for _, id := range chunk {
+				failed[id] = err
+			}
+			return
+		}
+		for _, item := range result {
+			items[item.Hostid] = append(items[item.Hostid], item)
+		}
+	}
+
+	if len(chunks) == 1 {
+		fetch(chunks[0])
+		return items, failed
+	}
+
+	jobs := make(chan []string)
+	var wg sync.WaitGroup
+	for w := 0; w < min(itemsWorkers, len(chunks)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				fetch(chunk)
+			}
+		}()
+	}
+
+	for _, chunk := range chunks {
+		jobs <- chunk
+	}
+	close(jobs)
+	wg.Wait()
+
+	return items, failed
+}
@@ -0,0 +1,154 @@
+package zabbix
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Maintenance é um período de manutenção do Zabbix
+type Maintenance struct {
+	ID          string
+	Name        string
+	Description string
+	ActiveSince time.Time
+	ActiveTill  time.Time
+	Hosts       []string
+	Groups      []string
+}
+
+// Active indica se a manutenção está vigente no momento
+func (m Maintenance) Active(now time.Time) bool {
+	return !now.Before(m.ActiveSince) && now.Before(m.ActiveTill)
+}
+
+// MaintenanceRequest descreve uma manutenção de período único a ser criada
+type MaintenanceRequest struct {
+	Name        string
+	Description string
+	HostIDs     []string
+	GroupIDs    []string
+	Start       time.Time
+	Duration    time.Duration
+}
+
+// CreateMaintenance cria uma manutenção com coleta de dados e devolve o seu ID
+func (c *Client) CreateMaintenance(ctx context.Context, req MaintenanceRequest) (string, error) {
+	v, err := c.Version(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	start := req.Start.Unix()
+	params := map[string]interface{}{
+		"name":         req.Name,
+		"description":  req.Description,
+		"active_since": start,
+		"active_till":  req.Start.Add(req.Duration).Unix(),
+		"timeperiods": []map[string]interface{}{{
+			"timeperiod_type": 0, /* período único */
+			"start_date":      start,
+			"period":          int64(req.Duration.Seconds()),
+		}},
+	}
+
+	// O Zabbix 6.0 trocou hostids/groupids por listas de objetos
+	if v.AtLeast(6, 0) {
+		hosts := make([]map[string]string, 0, len(req.HostIDs))
+		for _, id := range req.HostIDs {
+			hosts = append(hosts, map[string]string{"hostid": id})
+		}
+		groups := make([]map[string]string, 0, len(req.GroupIDs))
+		for _, id := range req.GroupIDs {
+			groups = append(groups, map[string]string{"groupid": id})
+		}
+		params["hosts"] = hosts
+		params["groups"] = groups
+	} else {
+		params["hostids"] = req.HostIDs
+		params["groupids"] = req.GroupIDs
+	}
+
+	resp, err := c.Call(ctx, "maintenance.create", params)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Maintenanceids []string `json:"maintenanceids"`
+	}
+	if err := unmarshal("maintenance.create", resp, &result); err != nil {
+		return "", err
+	}
+	if len(result.Maintenanceids) == 0 {
+		return "", &DecodeError{Method: "maintenance.create", Err: errEmptyResult}
+	}
+	c.invalidateInventory()
+	return result.Maintenanceids[0], nil
+}
+
+// GetMaintenances lista as manutenções cadastradas com seus hosts e grupos
+func (c *Client) GetMaintenances(ctx context.Context) ([]Maintenance, error) {
+	selectGroups, _, err := c.groupsSelector(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"output":      []string{"maintenanceid", "name", "description", "active_since", "active_till"},
+		"selectHosts": []string{"hostid", "host"},
+		selectGroups:  []string{"groupid", "name"},
+		"sortfield":   "name",
+	}
+
+	resp, err := c.Call(ctx, "maintenance.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Maintenanceid string `json:"maintenanceid"`
+		Name          string `json:"name"`
+		Description   string `json:"description"`
+		ActiveSince   string `json:"active_since"`
+		ActiveTill    string `json:"active_till"`
+		Hosts         []struct {
+			Host string `json:"host"`
+		} `json:"hosts"`
+		Groups     []HostGroup `json:"groups"`
+		HostGroups []HostGroup `json:"hostgroups"`
+	}
+	if err := unmarshal("maintenance.get", resp, &raw); err != nil {
+		return nil, err
+	}
+
+	maintenances := make([]Maintenance, 0, len(raw))
+	for _, r := range raw {
+		since, _ := strconv.ParseInt(r.ActiveSince, 10, 64)
+		till, _ := strconv.ParseInt(r.ActiveTill, 10, 64)
+		m := Maintenance{
+			ID:          r.Maintenanceid,
+			Name:        r.Name,
+			Description: r.Description,
+			ActiveSince: time.Unix(since, 0),
+			ActiveTill:  time.Unix(till, 0),
+		}
+		for _, h := range r.Hosts {
+			m.Hosts = append(m.Hosts, h.Host)
+		}
+		for _, g := range append(r.Groups, r.HostGroups...) {
+			m.Groups = append(m.Groups, g.Name)
+		}
+		maintenances = append(maintenances, m)
+	}
+	return maintenances, nil
+}
+
+// DeleteMaintenance remove as manutenções informadas
+func (c *Client) DeleteMaintenance(ctx context.Context, ids ...string) error {
+	if _, err := c.Call(ctx, "maintenance.delete", ids); err != nil {
+		return err
+	}
+	c.invalidateInventory()
+	return nil
+}
@@ -0,0 +1,57 @@
+package zabbix_test
+
+import (
+	"LapaTelegramBot/zabbix"
+	"LapaTelegramBot/zabbix/zabbixtest"
+	"context"
+	"testing"
+	"time"
+)
+
+// inventoryClient devolve um cliente com o inventário carregado, como em produção
+func inventoryClient(t *testing.T, s *zabbixtest.Server) *zabbix.Client {
+	t.Helper()
+	c := s.Client()
+	c.Inventory().Interval = time.Hour
+	if err := c.RefreshInventory(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestMaintenanceInvalidatesInventory(t *testing.T) {
+	s := zabbixtest.NewServer()
+	defer s.Close()
+	hostID := s.AddHost(zabbixtest.Host{Host: "SRV01"})
+	c := inventoryClient(t, s)
+	ctx := context.Background()
+
+	id, err := c.CreateMaintenance(ctx, zabbix.MaintenanceRequest{
+		Name:     "Teste",
+		HostIDs:  []string{hostID},
+		Start:    time.Now().Add(-time.Minute),
+		Duration: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, cached := c.InventoryAge(); cached {
+		t.Error("inventário continua válido após maintenance.create")
+	}
+	if host, _, err := c.GetHostByName(ctx, "SRV01"); err != nil || !host.InMaintenance() {
+		t.Errorf("GetHostByName = %+v, %v; esperado host em manutenção", host, err)
+	}
+
+	if err := c.RefreshInventory(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteMaintenance(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, cached := c.InventoryAge(); cached {
+		t.Error("inventário continua válido após maintenance.delete")
+	}
+	if host, _, err := c.GetHostByName(ctx, "SRV01"); err != nil || host.InMaintenance() {
+		t.Errorf("GetHostByName = %+v, %v; esperado host fora de manutenção", host, err)
+	}
+}
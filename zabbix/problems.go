@@ -0,0 +1,207 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Severidades de trigger do Zabbix
+const (
+	SeverityNotClassified = iota
+	SeverityInformation
+	SeverityWarning
+	SeverityAverage
+	SeverityHigh
+	SeverityDisaster
+)
+
+// SeverityNames são os nomes das severidades, indexados pelo valor numérico
+var SeverityNames = []string{"Não classificada", "Informação", "Atenção", "Média", "Alta", "Desastre"}
+
+// severityAliases aceita os nomes em português e em inglês usados na interface do Zabbix
+var severityAliases = map[string]int{
+	"nao_classificada": SeverityNotClassified,
+	"not_classified":   SeverityNotClassified,
+	"informacao":       SeverityInformation,
+	"info":             SeverityInformation,
+	"information":      SeverityInformation,
+	"atencao":          SeverityWarning,
+	"aviso":            SeverityWarning,
+	"warning":          SeverityWarning,
+	"media":            SeverityAverage,
+	"average":          SeverityAverage,
+	"alta":             SeverityHigh,
+	"high":             SeverityHigh,
+	"desastre":         SeverityDisaster,
+	"disaster":         SeverityDisaster,
+}
+
+// ParseSeverity converte "0".."5" ou o nome da severidade no valor numérico
+func ParseSeverity(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if n, err := strconv.Atoi(value); err == nil && n >= SeverityNotClassified && n <= SeverityDisaster {
+		return n, nil
+	}
+
+	replacer := strings.NewReplacer("ã", "a", "ç", "c", "é", "e", "í", "i", " ", "_")
+	if n, ok := severityAliases[replacer.Replace(value)]; ok {
+		return n, nil
+	}
+	return 0, fmt.Errorf("severidade inválida: %s", value)
+}
+
+// SeverityName devolve o nome da severidade
+func SeverityName(severity int) string {
+	if severity < 0 || severity >= len(SeverityNames) {
+		return strconv.Itoa(severity)
+	}
+	return SeverityNames[severity]
+}
+
+// Problem é um problema ativo do Zabbix, já associado aos hosts da trigger
+type Problem struct {
+	EventID      string
+	TriggerID    string
+	Name         string
+	Severity     int
+	Started      time.Time
+	Acknowledged bool
+	Hosts        []string
+	// Server é o nome do perfil do servidor Zabbix de origem
+	Server string
+}
+
+// ProblemFilter restringe os problemas retornados por GetProblems
+type ProblemFilter struct {
+	// MinSeverity descarta problemas com severidade menor
+	MinSeverity int
+	// GroupIDs restringe a hosts desses grupos
+	GroupIDs []string
+	// MaxAge descarta problemas iniciados há mais tempo; zero não limita
+	MaxAge time.Duration
+	// EventIDs restringe aos eventos informados
+	EventIDs []string
+}
+
+// GetProblems lista os problemas não resolvidos via problem.get, com os nomes dos
+// hosts obtidos por trigger.get, do mais recente para o mais antigo.
+func (c *Client) GetProblems(ctx context.Context, filter ProblemFilter) ([]Problem, error) {
+	var severities []int
+	for s := filter.MinSeverity; s <= SeverityDisaster; s++ {
+		severities = append(severities, s)
+	}
+
+	params := map[string]interface{}{
+		"output":     []string{"eventid", "objectid", "name", "severity", "clock", "acknowledged"},
+		"source":     0, /* eventos de trigger */
+		"object":     0,
+		"recent":     false,
+		"severities": severities,
+		"sortfield":  []string{"eventid"},
+		"sortorder":  "DESC",
+	}
+	if len(filter.GroupIDs) > 0 {
+		params["groupids"] = filter.GroupIDs
+	}
+	if len(filter.EventIDs) > 0 {
+		params["eventids"] = filter.EventIDs
+	}
+	if filter.MaxAge > 0 {
+		params["time_from"] = time.Now().Add(-filter.MaxAge).Unix()
+	}
+
+	resp, err := c.Call(ctx, "problem.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Eventid      string `json:"eventid"`
+		Objectid     string `json:"objectid"`
+		Name         string `json:"name"`
+		Severity     string `json:"severity"`
+		Clock        string `json:"clock"`
+		Acknowledged string `json:"acknowledged"`
+	}
+	if err := unmarshal("problem.get", resp, &raw); err != nil {
+		return nil, err
+	}
+
+	problems := make([]Problem, 0, len(raw))
+	triggerIDs := make([]string, 0, len(raw))
+	for _, r := range raw {
+		severity, _ := strconv.Atoi(r.Severity)
+		clock, _ := strconv.ParseInt(r.Clock, 10, 64)
+		problems = append(problems, Problem{
+			EventID:      r.Eventid,
+			TriggerID:    r.Objectid,
+			Name:         r.Name,
+			Severity:     severity,
+			Started:      time.Unix(clock, 0),
+			Acknowledged: r.Acknowledged == "1",
+			Server:       c.Name,
+		})
+		triggerIDs = append(triggerIDs, r.Objectid)
+	}
+
+	if len(triggerIDs) > 0 {
+		hosts, err := c.triggerHosts(ctx, triggerIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range problems {
+			problems[i].Hosts = hosts[problems[i].TriggerID]
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Started.After(problems[j].Started)
+	})
+	return problems, nil
+}
+
+// GetProblem devolve o problema ativo do evento informado; ok é falso quando o
+// problema já foi resolvido ou fechado
+func (c *Client) GetProblem(ctx context.Context, eventID string) (problem Problem, ok bool, err error) {
+	problems, err := c.GetProblems(ctx, ProblemFilter{EventIDs: []string{eventID}})
+	if err != nil || len(problems) == 0 {
+		return Problem{}, false, err
+	}
+	return problems[0], true, nil
+}
+
+// triggerHosts devolve os nomes dos hosts de cada trigger
+func (c *Client) triggerHosts(ctx context.Context, triggerIDs []string) (map[string][]string, error) {
+	params := map[string]interface{}{
+		"output":      []string{"triggerid"},
+		"triggerids":  triggerIDs,
+		"selectHosts": []string{"hostid", "host"},
+	}
+
+	resp, err := c.Call(ctx, "trigger.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var triggers []struct {
+		Triggerid string `json:"triggerid"`
+		Hosts     []struct {
+			Host string `json:"host"`
+		} `json:"hosts"`
+	}
+	if err := unmarshal("trigger.get", resp, &triggers); err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string][]string, len(triggers))
+	for _, t := range triggers {
+		for _, h := range t.Hosts {
+			hosts[t.Triggerid] = append(hosts[t.Triggerid], h.Host)
+		}
+	}
+	return hosts, nil
+}
@@ -0,0 +1,75 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Script é um script global do Zabbix
+type Script struct {
+	Scriptid    string `json:"scriptid"`
+	Name        string `json:"name"`
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// ScriptAllowed indica se o script está na lista de ZABBIX_ALLOWED_SCRIPTS.
+// Com a lista vazia nenhum script pode ser executado pelo bot.
+func (c *Client) ScriptAllowed(name string) bool {
+	for _, allowed := range c.AllowedScripts {
+		if strings.EqualFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHostScripts lista os scripts globais disponíveis para o host via
+// script.getscriptsbyhosts, mantendo apenas os permitidos na configuração.
+func (c *Client) GetHostScripts(ctx context.Context, hostID string) ([]Script, error) {
+	resp, err := c.Call(ctx, "script.getscriptsbyhosts", []string{hostID})
+	if err != nil {
+		return nil, err
+	}
+
+	var byHost map[string][]Script
+	if err := unmarshal("script.getscriptsbyhosts", resp, &byHost); err != nil {
+		return nil, err
+	}
+
+	var scripts []Script
+	for _, s := range byHost[hostID] {
+		if c.ScriptAllowed(s.Name) {
+			scripts = append(scripts, s)
+		}
+	}
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].Name < scripts[j].Name })
+	return scripts, nil
+}
+
+// ExecuteScript executa o script no host via script.execute e devolve a saída
+func (c *Client) ExecuteScript(ctx context.Context, scriptID, hostID string) (string, error) {
+	params := map[string]string{
+		"scriptid": scriptID,
+		"hostid":   hostID,
+	}
+
+	resp, err := c.Call(ctx, "script.execute", params)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Response string `json:"response"`
+		Value    string `json:"value"`
+	}
+	if err := unmarshal("script.execute", resp, &result); err != nil {
+		return "", err
+	}
+	if result.Response != "" && result.Response != "success" {
+		return result.Value, fmt.Errorf("zabbix script.execute: %s", result.Value)
+	}
+	return result.Value, nil
+}
@@ -0,0 +1,108 @@
+package zabbix
+
+import (
+	"LapaTelegramBot/config"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// defaultServerName é o nome do perfil quando ZABBIX_SERVERS não está definido
+const defaultServerName = "principal"
+
+// profileEnv é o prefixo das variáveis de um perfil, ex: "ZABBIX_MATRIZ_".
+// Vazio indica as variáveis globais ZABBIX_*.
+type profileEnv string
+
+// newProfileEnv monta o prefixo do perfil: "filial-sp" vira "ZABBIX_FILIAL_SP_"
+func newProfileEnv(name string) profileEnv {
+	upper := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+	return profileEnv("ZABBIX_" + upper + "_")
+}
+
+// own devolve a variável exclusiva do perfil. Usada para URL e credenciais, que
+// nunca são herdadas da configuração global.
+func (p profileEnv) own(global string) string {
+	if p == "" {
+		return global
+	}
+	return string(p) + strings.TrimPrefix(global, "ZABBIX_")
+}
+
+// key devolve a variável do perfil se estiver definida; senão, a global, que
+// funciona como padrão para todos os perfis (ex: ZABBIX_TIMEOUT).
+func (p profileEnv) key(global string) string {
+	if p == "" {
+		return global
+	}
+	if k := p.own(global); os.Getenv(k) != "" {
+		return k
+	}
+	return global
+}
+
+// Servers reúne os perfis de servidores Zabbix configurados, na ordem de ZABBIX_SERVERS
+type Servers struct {
+	clients []*Client
+}
+
+// LoadServers cria um cliente para cada perfil de ZABBIX_SERVERS (ex: "matriz,filial"),
+// lendo ZABBIX_<PERFIL>_API_URL, ZABBIX_<PERFIL>_API_TOKEN etc. Sem a variável, há
+// um único perfil com as variáveis ZABBIX_* globais.
+func LoadServers() *Servers {
+	names := config.SplitList(os.Getenv("ZABBIX_SERVERS"))
+	if len(names) == 0 {
+		return &Servers{clients: []*Client{NewClient()}}
+	}
+
+	s := &Servers{}
+	for _, name := range names {
+		s.clients = append(s.clients, newProfileClient(name, newProfileEnv(name)))
+	}
+	return s
+}
+
+// NewServers reúne clientes já configurados, ex: o cliente do modo demonstração.
+// O primeiro é o perfil padrão.
+func NewServers(clients ...*Client) *Servers {
+	return &Servers{clients: clients}
+}
+
+// Default devolve o primeiro perfil configurado
+func (s *Servers) Default() *Client {
+	return s.clients[0]
+}
+
+// Get busca o perfil pelo nome, sem diferenciar maiúsculas
+func (s *Servers) Get(name string) (*Client, bool) {
+	for _, c := range s.clients {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// All devolve todos os perfis
+func (s *Servers) All() []*Client {
+	return s.clients
+}
+
+// Names devolve os nomes dos perfis
+func (s *Servers) Names() []string {
+	names := make([]string, len(s.clients))
+	for i, c := range s.clients {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Multiple indica se há mais de um servidor configurado
+func (s *Servers) Multiple() bool {
+	return len(s.clients) > 1
+}
@@ -1,8 +1,15 @@
 package zabbix
 
-import "encoding/json"
+import (
+	"context"
+	"regexp"
+)
+
+// serviceNameRe extrai o nome do serviço do nome do item, ex: State of service "TOTVSAppServer"
+var serviceNameRe = regexp.MustCompile(`"([^"]+)"`)
 
 type ServiceStatus struct {
+	Itemid    string `json:"itemid"`
 	Hostid    string `json:"hostid"`
 	Name      string `json:"name"`
 	Status    string `json:"status"` /* 0 - Ativo 1 - Inativo */
@@ -10,20 +17,40 @@ type ServiceStatus struct {
 	Prevvalue string `json:"prevvalue"`
 }
 
-func (c *Client) GetProtheusServiceStatus() ([]ServiceStatus, error) {
+// ServiceName devolve o nome do serviço entre aspas no nome do item, ou o nome do item
+func (s ServiceStatus) ServiceName() string {
+	if match := serviceNameRe.FindStringSubmatch(s.Name); len(match) > 1 {
+		return match[1]
+	}
+	return s.Name
+}
+
+// Running indica se o serviço estava em execução (state 0) nas duas últimas coletas
+func (s ServiceStatus) Running() bool {
+	return s.Lastvalue == "0" && s.Prevvalue == "0"
+}
+
+func (c *Client) GetProtheusServiceStatus(ctx context.Context) ([]ServiceStatus, error) {
+	groupID, err := c.GroupID(ctx, c.Groups.Protheus)
+	if err != nil {
+		return nil, err
+	}
+
 	params := map[string]interface{}{
-		"output":  "extend",
-		"groupid": "25",
+		"output":   "extend",
+		"groupids": groupID,
 		"search": map[string]string{
-			"key_": "TOTVS",
+			"key_": c.Groups.ProtheusKey,
 		},
 	}
-	resp, err := c.Call("item.get", params)
+	resp, err := c.Call(ctx, "item.get", params)
 	if err != nil {
 		return nil, err
 	}
 
 	var services []ServiceStatus
-	json.Unmarshal(resp, &services)
+	if err := unmarshal("item.get", resp, &services); err != nil {
+		return nil, err
+	}
 	return services, nil
 }
@@ -0,0 +1,202 @@
+package zabbixtest
+
+import (
+	"LapaTelegramBot/zabbix"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Grupos do ambiente de demonstração, com os nomes padrão da configuração do bot
+const (
+	DemoServersGroup  = "Servidores"
+	DemoPrintersGroup = "Impressoras"
+	DemoProtheusGroup = "Protheus"
+	DemoAppsGroup     = "Applications"
+	DemoBranchesGroup = "Filiais"
+)
+
+// demoHistory é o período de histórico gerado para os itens numéricos
+const demoHistory = 7 * 24 * time.Hour
+
+// NewDemoServer inicia um servidor com um ambiente de exemplo: servidores, impressoras,
+// serviços do Protheus, uma filial offline, problemas abertos, uma manutenção
+// ativa e scripts globais. Usado pelo modo demonstração do bot (ZABBIX_DEMO=true).
+func NewDemoServer() *Server {
+	s := NewServer()
+	now := time.Now()
+
+	groups := make(map[string]string)
+	for _, name := range []string{DemoServersGroup, DemoPrintersGroup, DemoProtheusGroup, DemoAppsGroup, DemoBranchesGroup} {
+		groups[name] = s.AddGroup(name)
+	}
+	linux := s.AddTemplate("Linux by Zabbix agent")
+	windows := s.AddTemplate("Windows by Zabbix agent")
+	snmp := s.AddTemplate("Generic by SNMP")
+	s.AddTemplate("ICMP Ping")
+
+	agent := func(ip string) []zabbix.Interface {
+		return []zabbix.Interface{{Type: "1", IP: ip, Port: "10050"}}
+	}
+	snmpIface := func(ip string) []zabbix.Interface {
+		return []zabbix.Interface{{Type: "2", IP: ip, Port: "161"}}
+	}
+
+	hosts := []struct {
+		host     Host
+		online   bool
+		cpu      float64
+		disk     float64
+		printer  bool
+		protheus bool
+	}{
+		{host: Host{Host: "SRV-AD01", Name: "Controlador de domínio", GroupIDs: []string{groups[DemoServersGroup]}, TemplateIDs: []string{windows}, Interfaces: agent("10.0.0.10"),
+			Inventory: map[string]string{"os": "Windows Server 2022", "location": "CPD Matriz"}, Tags: []zabbix.Tag{{Tag: "site", Value: "matriz"}}},
+			online: true, cpu: 18, disk: 61},
+		{host: Host{Host: "SRV-FILES", GroupIDs: []string{groups[DemoServersGroup]}, TemplateIDs: []string{windows}, Interfaces: agent("10.0.0.11"),
+			Tags: []zabbix.Tag{{Tag: "site", Value: "matriz"}}},
+			online: true, cpu: 42, disk: 93},
+		{host: Host{Host: "SRV-PROTHEUS", Name: "Protheus AppServer", GroupIDs: []string{groups[DemoServersGroup], groups[DemoProtheusGroup]}, TemplateIDs: []string{windows}, Interfaces: agent("10.0.0.20"),
+			Tags: []zabbix.Tag{{Tag: "site", Value: "matriz"}}},
+			online: true, cpu: 71, disk: 48, protheus: true},
+		{host: Host{Host: "SRV-WEB01", GroupIDs: []string{groups[DemoServersGroup], groups[DemoAppsGroup]}, TemplateIDs: []string{linux}, Interfaces: agent("10.0.0.30"),
+			Tags: []zabbix.Tag{{Tag: "site", Value: "matriz"}}},
+			online: true, cpu: 9, disk: 37},
+		{host: Host{Host: "FW-MATRIZ", Name: "Firewall Matriz", GroupIDs: []string{groups[DemoServersGroup]}, TemplateIDs: []string{snmp}, Interfaces: snmpIface("10.0.0.1"),
+			Tags: []zabbix.Tag{{Tag: "site", Value: "matriz"}}},
+			online: true, cpu: 23},
+		{host: Host{Host: "IMP-RH", GroupIDs: []string{groups[DemoPrintersGroup]}, TemplateIDs: []string{snmp}, Interfaces: snmpIface("10.0.1.50")},
+			online: true, printer: true},
+		{host: Host{Host: "IMP-FINANCEIRO", GroupIDs: []string{groups[DemoPrintersGroup]}, TemplateIDs: []string{snmp}, Interfaces: snmpIface("10.0.1.51")},
+			online: true, printer: true},
+		{host: Host{Host: "RT-FILIAL-SP", Name: "Roteador Filial SP", GroupIDs: []string{groups[DemoBranchesGroup]}, TemplateIDs: []string{snmp}, Interfaces: snmpIface("10.1.0.1"),
+			Tags: []zabbix.Tag{{Tag: "site", Value: "filial-sp"}}},
+			online: false},
+		{host: Host{Host: "SRV-FILIAL-SP", GroupIDs: []string{groups[DemoServersGroup], groups[DemoBranchesGroup]}, TemplateIDs: []string{windows}, Interfaces: agent("10.1.0.10"),
+			Tags: []zabbix.Tag{{Tag: "site", Value: "filial-sp"}, {Tag: "parent", Value: "RT-FILIAL-SP"}}},
+			online: false, cpu: 12, disk: 55},
+		{host: Host{Host: "SRV-BACKUP", GroupIDs: []string{groups[DemoServersGroup]}, TemplateIDs: []string{linux}, Interfaces: agent("10.0.0.40")},
+			online: true, cpu: 4, disk: 78},
+	}
+
+	ids := make(map[string]string)
+	for _, h := range hosts {
+		id := s.AddHost(h.host)
+		ids[h.host.Host] = id
+
+		ping := "1"
+		if !h.online {
+			ping = "0"
+			s.hosts[len(s.hosts)-1].Interfaces[0].Available = "2"
+		}
+		s.addDemoItem(id, "ICMP ping", "icmpping", zabbix.ValueUnsigned, "", ping, ping, now)
+
+		if h.cpu > 0 {
+			item := s.addDemoItem(id, "CPU utilization", "system.cpu.util", zabbix.ValueFloat, "%", "", "", now)
+			s.fillHistory(item, now, h.cpu, 15)
+		}
+		if h.disk > 0 {
+			item := s.addDemoItem(id, "C:: Space utilization", "vfs.fs.size[C:,pused]", zabbix.ValueFloat, "%", "", "", now)
+			s.fillHistory(item, now, h.disk, 1)
+			s.addDemoItem(id, "C:: Total space", "vfs.fs.size[C:,total]", zabbix.ValueUnsigned, "B", "536870912000", "536870912000", now)
+			s.addDemoItem(id, "System uptime", "system.uptime", zabbix.ValueUnsigned, "uptime", "1296000", "1295940", now)
+		}
+		if h.printer {
+			base := 120000 + len(ids)*7531
+			s.addDemoItem(id, "Contador preto e branco", "contador.peb", zabbix.ValueUnsigned, "", fmt.Sprint(base), fmt.Sprint(base-40), now)
+			s.addDemoItem(id, "Contador colorido", "contador.colorido", zabbix.ValueUnsigned, "", fmt.Sprint(base/4), fmt.Sprint(base/4-12), now)
+			s.addDemoItem(id, "Contador total", "contador.total", zabbix.ValueUnsigned, "", fmt.Sprint(base+base/4), fmt.Sprint(base+base/4-52), now)
+			toner := "54"
+			if h.host.Host == "IMP-RH" {
+				toner = "8" /* abaixo do limite padrão do monitor de toner */
+			}
+			s.addDemoItem(id, "Toner preto", "toner.preto", zabbix.ValueUnsigned, "%", toner, toner, now)
+		}
+		if h.protheus {
+			for _, svc := range []string{"TOTVSAppServer", "TOTVSDbAccess", "TOTVSLicense"} {
+				state := "0"
+				if svc == "TOTVSLicense" {
+					state = "3" /* parado */
+				}
+				s.addDemoItem(id, fmt.Sprintf("State of service \"%s\"", svc), fmt.Sprintf("service.info[%s,state]", svc), zabbix.ValueUnsigned, "", state, state, now)
+			}
+		}
+	}
+
+	s.AddProblem(Problem{Name: "Unavailable by ICMP ping", Severity: zabbix.SeverityHigh, Clock: now.Add(-47 * time.Minute), HostIDs: []string{ids["RT-FILIAL-SP"]}})
+	s.AddProblem(Problem{Name: "Unavailable by ICMP ping", Severity: zabbix.SeverityHigh, Clock: now.Add(-45 * time.Minute), HostIDs: []string{ids["SRV-FILIAL-SP"]}})
+	s.AddProblem(Problem{Name: "C:: Disk space is critically low (used > 90%)", Severity: zabbix.SeverityAverage, Clock: now.Add(-26 * time.Hour), HostIDs: []string{ids["SRV-FILES"]}, Acknowledged: true})
+	s.AddProblem(Problem{Name: "\"TOTVSLicense\" is not running", Severity: zabbix.SeverityDisaster, Clock: now.Add(-8 * time.Minute), HostIDs: []string{ids["SRV-PROTHEUS"]}})
+	s.AddProblem(Problem{Name: "High CPU utilization (over 70% for 5m)", Severity: zabbix.SeverityWarning, Clock: now.Add(-3 * time.Minute), HostIDs: []string{ids["SRV-PROTHEUS"]}})
+
+	s.AddMaintenance(Maintenance{
+		Name:        "Atualização do backup",
+		Description: "Janela de atualização do servidor de backup",
+		ActiveSince: now.Add(-30 * time.Minute),
+		ActiveTill:  now.Add(90 * time.Minute),
+		HostIDs:     []string{ids["SRV-BACKUP"]},
+	})
+
+	s.AddScript(Script{Name: "Ping", Command: "ping -c 3 {HOST.CONN}", Output: "PING 10.0.0.10: 56 data bytes\n64 bytes from 10.0.0.10: icmp_seq=0 ttl=64 time=0.48 ms\n64 bytes from 10.0.0.10: icmp_seq=1 ttl=64 time=0.51 ms\n64 bytes from 10.0.0.10: icmp_seq=2 ttl=64 time=0.44 ms\n\n3 packets transmitted, 3 received, 0% packet loss"})
+	s.AddScript(Script{Name: "Traceroute", Command: "traceroute {HOST.CONN}", Output: "traceroute to 10.0.0.10, 30 hops max\n 1  10.0.0.1  0.32 ms\n 2  10.0.0.10  0.61 ms"})
+	s.AddScript(Script{Name: "Reiniciar serviço", Command: "sc restart {$SERVICE}", Output: "Serviço reiniciado."})
+
+	return s
+}
+
+// DemoClient cria o cliente do modo demonstração, com os grupos do ambiente de exemplo
+func (s *Server) DemoClient() *zabbix.Client {
+	c := s.Client()
+	c.Name = "demo"
+	c.Groups = zabbix.Groups{
+		Printers:       DemoPrintersGroup,
+		Protheus:       DemoProtheusGroup,
+		ProtheusKey:    "TOTVS",
+		MonitorExclude: []string{DemoAppsGroup, DemoPrintersGroup},
+	}
+	c.AllowedScripts = []string{"Ping", "Traceroute"}
+	return c
+}
+
+// addDemoItem adiciona um item já coletado e devolve o seu ID
+func (s *Server) addDemoItem(hostID, name, key, valueType, units, last, prev string, now time.Time) string {
+	return s.AddItem(Item{Item: zabbix.Item{
+		Hostid:    hostID,
+		Name:      name,
+		Key:       key,
+		ValueType: valueType,
+		Units:     units,
+		Lastvalue: last,
+		Prevvalue: prev,
+		Lastclock: unix(now.Add(-30 * time.Second)),
+	}})
+}
+
+// fillHistory gera uma curva diária em torno de base, a cada 10 minutos, e atualiza
+// o último valor e o anterior do item com os dois pontos mais recentes
+func (s *Server) fillHistory(itemID string, now time.Time, base, amplitude float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var item *Item
+	for _, i := range s.items {
+		if i.Itemid == itemID {
+			item = i
+		}
+	}
+	if item == nil {
+		return
+	}
+
+	for t := now.Add(-demoHistory); !t.After(now); t = t.Add(10 * time.Minute) {
+		hour := float64(t.Hour()) + float64(t.Minute())/60
+		v := base + amplitude*math.Sin((hour-9)*math.Pi/12) + amplitude/5*math.Sin(float64(t.Unix())/977)
+		v = math.Round(math.Max(0, math.Min(100, v))*100) / 100
+		item.History = append(item.History, zabbix.HistoryPoint{Clock: t, Value: v})
+	}
+
+	n := len(item.History)
+	item.Lastvalue = formatFloat(item.History[n-1].Value)
+	item.Prevvalue = formatFloat(item.History[n-2].Value)
+	item.Lastclock = unix(item.History[n-1].Clock)
+}
@@ -0,0 +1,753 @@
+package zabbixtest
+
+import (
+	"LapaTelegramBot/zabbix"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type object = map[string]interface{}
+
+// group devolve o grupo pelo ID
+func (s *Server) group(id string) (*Group, bool) {
+	for _, g := range s.groups {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+// host devolve o host pelo ID
+func (s *Server) host(id string) (*Host, bool) {
+	for _, h := range s.hosts {
+		if h.ID == id {
+			return h, true
+		}
+	}
+	return nil, false
+}
+
+// inMaintenance indica se o host está em manutenção agora, direta ou por um grupo
+func (s *Server) inMaintenance(h *Host) bool {
+	if h.Maintenance {
+		return true
+	}
+	now := time.Now()
+	for _, m := range s.maintenances {
+		if now.Before(m.ActiveSince) || !now.Before(m.ActiveTill) {
+			continue
+		}
+		if contains(m.HostIDs, h.ID) || len(m.GroupIDs) > 0 && intersects(m.GroupIDs, h.GroupIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) groupObjects(ids []string) []object {
+	list := []object{}
+	for _, id := range ids {
+		if g, ok := s.group(id); ok {
+			list = append(list, object{"groupid": g.ID, "name": g.Name})
+		}
+	}
+	return list
+}
+
+// groupsKey devolve a chave usada para os grupos na resposta: selectGroups ou selectHostGroups
+func groupsKey(p params) string {
+	if p.has("selectHostGroups") {
+		return "hostgroups"
+	}
+	if p.has("selectGroups") {
+		return "groups"
+	}
+	return ""
+}
+
+// status converte o booleano nos valores "0" e "1" usados pela API
+func status(on bool) string {
+	if on {
+		return "1"
+	}
+	return "0"
+}
+
+func (s *Server) hostGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	hostIDs := p.list("hostids")
+	groupIDs := p.list("groupids")
+	groups := groupsKey(p)
+
+	result := []object{}
+	for _, h := range s.hosts {
+		fields := map[string]string{
+			"hostid":             h.ID,
+			"host":               h.Host,
+			"name":               h.Name,
+			"status":             status(h.Disabled),
+			"maintenance_status": status(s.inMaintenance(h)),
+		}
+
+		if !matches(hostIDs, h.ID) || !intersects(groupIDs, h.GroupIDs) {
+			continue
+		}
+		if !filtersMatch(p, fields) {
+			continue
+		}
+
+		o := object{}
+		for k, v := range fields {
+			o[k] = v
+		}
+		if groups != "" {
+			o[groups] = s.groupObjects(h.GroupIDs)
+		}
+		if p.has("selectInterfaces") {
+			o["interfaces"] = nonNil(h.Interfaces)
+		}
+		if p.has("selectParentTemplates") {
+			templates := []object{}
+			for _, t := range s.templates {
+				if contains(h.TemplateIDs, t.ID) {
+					templates = append(templates, object{"templateid": t.ID, "name": t.Name})
+				}
+			}
+			o["parentTemplates"] = templates
+		}
+		if p.has("selectInventory") {
+			// Como no Zabbix, o inventário desativado vem como lista vazia
+			if len(h.Inventory) == 0 {
+				o["inventory"] = []interface{}{}
+			} else {
+				o["inventory"] = h.Inventory
+			}
+		}
+		if p.has("selectTags") {
+			o["tags"] = nonNil(h.Tags)
+		}
+		result = append(result, o)
+	}
+	return result, nil
+}
+
+// filtersMatch aplica o "filter" da requisição aos campos do objeto
+func filtersMatch(p params, fields map[string]string) bool {
+	for field, value := range fields {
+		if values, ok := p.filter(field); ok && !matches(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func nonNil[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
+
+func (s *Server) hostCreate(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	name := p.str("host")
+	if name == "" {
+		return nil, &Error{Code: -32602, Message: "Invalid params.", Data: "Incorrect value for field \"host\": cannot be empty."}
+	}
+	for _, h := range s.hosts {
+		if h.Host == name {
+			return nil, &Error{Code: -32602, Message: "Invalid params.", Data: fmt.Sprintf("Host with the same name \"%s\" already exists.", name)}
+		}
+	}
+
+	groupIDs := p.objectIDs("groups", "groupid")
+	if len(groupIDs) == 0 {
+		return nil, &Error{Code: -32602, Message: "Invalid params.", Data: "Host \"" + name + "\" cannot be without host group."}
+	}
+	for _, id := range groupIDs {
+		if _, ok := s.group(id); !ok {
+			return nil, notFound("hostgroup " + id)
+		}
+	}
+
+	var interfaces []map[string]json.RawMessage
+	json.Unmarshal(p["interfaces"], &interfaces)
+
+	h := Host{Host: name, GroupIDs: groupIDs, TemplateIDs: p.objectIDs("templates", "templateid")}
+	for _, i := range interfaces {
+		h.Interfaces = append(h.Interfaces, interfaceFrom(params(i)))
+	}
+
+	id := s.addHost(h)
+	return object{"hostids": []string{id}}, nil
+}
+
+// interfaceFrom converte os parâmetros de hostinterface.create ou host.create em interface
+func interfaceFrom(p params) zabbix.Interface {
+	return zabbix.Interface{
+		Type:      p.str("type"),
+		Main:      p.str("main"),
+		UseIP:     p.str("useip"),
+		IP:        p.str("ip"),
+		DNS:       p.str("dns"),
+		Port:      p.str("port"),
+		Available: "0",
+	}
+}
+
+func (s *Server) hostUpdate(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	h, ok := s.host(p.str("hostid"))
+	if !ok {
+		return nil, notFound("host " + p.str("hostid"))
+	}
+	if p.has("status") {
+		h.Disabled = p.str("status") == "1"
+	}
+	if p.has("name") {
+		h.Name = p.str("name")
+	}
+	return object{"hostids": []string{h.ID}}, nil
+}
+
+func (s *Server) hostMassAdd(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	hostIDs := p.objectIDs("hosts", "hostid")
+	for _, id := range hostIDs {
+		h, ok := s.host(id)
+		if !ok {
+			return nil, notFound("host " + id)
+		}
+		for _, g := range p.objectIDs("groups", "groupid") {
+			if !contains(h.GroupIDs, g) {
+				h.GroupIDs = append(h.GroupIDs, g)
+			}
+		}
+		for _, t := range p.objectIDs("templates", "templateid") {
+			if !contains(h.TemplateIDs, t) {
+				h.TemplateIDs = append(h.TemplateIDs, t)
+			}
+		}
+	}
+	return object{"hostids": hostIDs}, nil
+}
+
+func (s *Server) hostgroupGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := p.list("groupids")
+	result := []object{}
+	for _, g := range s.groups {
+		if !matches(groupIDs, g.ID) || !filtersMatch(p, map[string]string{"groupid": g.ID, "name": g.Name}) {
+			continue
+		}
+		result = append(result, object{"groupid": g.ID, "name": g.Name})
+	}
+	return result, nil
+}
+
+func (s *Server) templateGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []object{}
+	for _, t := range s.templates {
+		if !filtersMatch(p, map[string]string{"templateid": t.ID, "host": t.Host, "name": t.Name}) {
+			continue
+		}
+		result = append(result, object{"templateid": t.ID, "host": t.Host, "name": t.Name})
+	}
+	return result, nil
+}
+
+func (s *Server) hostinterfaceGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	hostIDs := p.list("hostids")
+	result := []object{}
+	for _, h := range s.hosts {
+		if !matches(hostIDs, h.ID) {
+			continue
+		}
+		for _, i := range h.Interfaces {
+			if !filtersMatch(p, map[string]string{"interfaceid": i.Interfaceid, "hostid": h.ID, "ip": i.IP, "dns": i.DNS, "type": i.Type}) {
+				continue
+			}
+			o := object{"interfaceid": i.Interfaceid, "hostid": h.ID, "ip": i.IP, "dns": i.DNS, "type": i.Type, "main": i.Main, "port": i.Port}
+			if p.has("selectHosts") {
+				o["hosts"] = []object{{"hostid": h.ID, "host": h.Host}}
+			}
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) hostinterfaceCreate(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	h, ok := s.host(p.str("hostid"))
+	if !ok {
+		return nil, notFound("host " + p.str("hostid"))
+	}
+
+	iface := interfaceFrom(p)
+	iface.Interfaceid = s.newID()
+	h.Interfaces = append(h.Interfaces, iface)
+	return object{"interfaceids": []string{iface.Interfaceid}}, nil
+}
+
+func (s *Server) hostinterfaceUpdate(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	id := p.str("interfaceid")
+	for _, h := range s.hosts {
+		for i := range h.Interfaces {
+			iface := &h.Interfaces[i]
+			if iface.Interfaceid != id {
+				continue
+			}
+			if p.has("ip") {
+				iface.IP = p.str("ip")
+			}
+			if p.has("port") {
+				iface.Port = p.str("port")
+			}
+			return object{"interfaceids": []string{id}}, nil
+		}
+	}
+	return nil, notFound("interface " + id)
+}
+
+// itemObject é a resposta de item.get: o item e o seu status (usado no /protheus_status)
+type itemObject struct {
+	zabbix.Item
+	Status string `json:"status"`
+}
+
+func (s *Server) itemGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	itemIDs := p.list("itemids")
+	hostIDs := p.list("hostids")
+	groupIDs := p.list("groupids")
+	keySearch := p.search("key_")
+	wildcards := p.flag("searchWildcardsEnabled")
+
+	result := []itemObject{}
+	for _, item := range s.items {
+		if !matches(itemIDs, item.Itemid) || !matches(hostIDs, item.Hostid) {
+			continue
+		}
+		if len(groupIDs) > 0 {
+			h, ok := s.host(item.Hostid)
+			if !ok || !intersects(groupIDs, h.GroupIDs) {
+				continue
+			}
+		}
+		if !searchMatch(item.Key, keySearch, wildcards) {
+			continue
+		}
+		if !filtersMatch(p, map[string]string{"key_": item.Key, "name": item.Name}) {
+			continue
+		}
+		result = append(result, itemObject{Item: item.Item, Status: "0"})
+	}
+
+	if p.str("sortfield") == "name" {
+		sort.SliceStable(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	}
+	return result, nil
+}
+
+// historyPoints devolve os pontos dos itens no período informado, em ordem cronológica
+func (s *Server) historyPoints(p params) map[string][]zabbix.HistoryPoint {
+	itemIDs := p.list("itemids")
+	from := time.Unix(p.num("time_from"), 0)
+	till := time.Now().Add(time.Hour)
+	if p.has("time_till") {
+		till = time.Unix(p.num("time_till"), 0)
+	}
+
+	points := make(map[string][]zabbix.HistoryPoint)
+	for _, item := range s.items {
+		if !matches(itemIDs, item.Itemid) {
+			continue
+		}
+		for _, h := range item.History {
+			if !h.Clock.Before(from) && !h.Clock.After(till) {
+				points[item.Itemid] = append(points[item.Itemid], h)
+			}
+		}
+		sort.Slice(points[item.Itemid], func(i, j int) bool {
+			return points[item.Itemid][i].Clock.Before(points[item.Itemid][j].Clock)
+		})
+	}
+	return points
+}
+
+func (s *Server) historyGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []object{}
+	for itemID, points := range s.historyPoints(p) {
+		for _, h := range points {
+			result = append(result, object{"itemid": itemID, "clock": unix(h.Clock), "value": formatFloat(h.Value)})
+		}
+	}
+
+	desc := p.str("sortorder") == "DESC"
+	sort.SliceStable(result, func(i, j int) bool {
+		ci, _ := strconv.ParseInt(result[i]["clock"].(string), 10, 64)
+		cj, _ := strconv.ParseInt(result[j]["clock"].(string), 10, 64)
+		if desc {
+			return ci > cj
+		}
+		return ci < cj
+	})
+	return result, nil
+}
+
+// trendGet agrega o histórico em médias horárias, como as trends do Zabbix
+func (s *Server) trendGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []object{}
+	for itemID, points := range s.historyPoints(p) {
+		type bucket struct {
+			sum, min, max float64
+			n             int
+		}
+		hours := make(map[int64]*bucket)
+		for _, h := range points {
+			hour := h.Clock.Truncate(time.Hour).Unix()
+			b, ok := hours[hour]
+			if !ok {
+				b = &bucket{min: h.Value, max: h.Value}
+				hours[hour] = b
+			}
+			b.sum += h.Value
+			b.n++
+			b.min = min(b.min, h.Value)
+			b.max = max(b.max, h.Value)
+		}
+		for hour, b := range hours {
+			result = append(result, object{
+				"itemid":    itemID,
+				"clock":     strconv.FormatInt(hour, 10),
+				"num":       strconv.Itoa(b.n),
+				"value_avg": formatFloat(b.sum / float64(b.n)),
+				"value_min": formatFloat(b.min),
+				"value_max": formatFloat(b.max),
+			})
+		}
+	}
+	return result, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (s *Server) problemGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	eventIDs := p.list("eventids")
+	groupIDs := p.list("groupids")
+	severities := p.list("severities")
+	from := p.num("time_from")
+
+	var problems []*Problem
+	for _, pr := range s.problems {
+		if !matches(eventIDs, pr.EventID) || !matches(severities, strconv.Itoa(pr.Severity)) {
+			continue
+		}
+		if from > 0 && pr.Clock.Unix() < from {
+			continue
+		}
+		if len(groupIDs) > 0 && !s.problemInGroups(pr, groupIDs) {
+			continue
+		}
+		problems = append(problems, pr)
+	}
+
+	// Como o bot pede sortfield eventid DESC, os IDs maiores vêm primeiro
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, _ := strconv.Atoi(problems[i].EventID)
+		b, _ := strconv.Atoi(problems[j].EventID)
+		return a > b
+	})
+
+	result := []object{}
+	for _, pr := range problems {
+		ack := "0"
+		if pr.Acknowledged {
+			ack = "1"
+		}
+		result = append(result, object{
+			"eventid":      pr.EventID,
+			"objectid":     pr.TriggerID,
+			"name":         pr.Name,
+			"severity":     strconv.Itoa(pr.Severity),
+			"clock":        unix(pr.Clock),
+			"acknowledged": ack,
+		})
+	}
+	return result, nil
+}
+
+func (s *Server) problemInGroups(pr *Problem, groupIDs []string) bool {
+	for _, id := range pr.HostIDs {
+		if h, ok := s.host(id); ok && intersects(groupIDs, h.GroupIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) triggerGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	triggerIDs := p.list("triggerids")
+	seen := make(map[string]bool)
+	result := []object{}
+	for _, pr := range s.problems {
+		if !matches(triggerIDs, pr.TriggerID) || seen[pr.TriggerID] {
+			continue
+		}
+		seen[pr.TriggerID] = true
+
+		o := object{"triggerid": pr.TriggerID, "description": pr.Name}
+		if p.has("selectHosts") {
+			hosts := []object{}
+			for _, id := range pr.HostIDs {
+				if h, ok := s.host(id); ok {
+					hosts = append(hosts, object{"hostid": h.ID, "host": h.Host})
+				}
+			}
+			o["hosts"] = hosts
+		}
+		result = append(result, o)
+	}
+	return result, nil
+}
+
+func (s *Server) eventAcknowledge(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		actionClose          = 1
+		actionAcknowledge    = 2
+		actionMessage        = 4
+		actionChangeSeverity = 8
+		actionUnacknowledge  = 16
+	)
+	action := p.num("action")
+	eventIDs := p.list("eventids")
+
+	for _, id := range eventIDs {
+		var problem *Problem
+		for _, pr := range s.problems {
+			if pr.EventID == id {
+				problem = pr
+			}
+		}
+		if problem == nil {
+			return nil, notFound("event " + id)
+		}
+
+		if action&actionAcknowledge != 0 {
+			problem.Acknowledged = true
+		}
+		if action&actionUnacknowledge != 0 {
+			problem.Acknowledged = false
+		}
+		if action&actionMessage != 0 {
+			problem.Messages = append(problem.Messages, p.str("message"))
+		}
+		if action&actionChangeSeverity != 0 {
+			problem.Severity = int(p.num("severity"))
+		}
+		if action&actionClose != 0 {
+			s.removeProblem(id)
+		}
+	}
+	return object{"eventids": eventIDs}, nil
+}
+
+func (s *Server) maintenanceGet(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := p.list("maintenanceids")
+	groups := groupsKey(p)
+
+	result := []object{}
+	for _, m := range s.maintenances {
+		if !matches(ids, m.ID) {
+			continue
+		}
+		o := object{
+			"maintenanceid": m.ID,
+			"name":          m.Name,
+			"description":   m.Description,
+			"active_since":  unix(m.ActiveSince),
+			"active_till":   unix(m.ActiveTill),
+		}
+		if p.has("selectHosts") {
+			hosts := []object{}
+			for _, id := range m.HostIDs {
+				if h, ok := s.host(id); ok {
+					hosts = append(hosts, object{"hostid": h.ID, "host": h.Host})
+				}
+			}
+			o["hosts"] = hosts
+		}
+		if groups != "" {
+			o[groups] = s.groupObjects(m.GroupIDs)
+		}
+		result = append(result, o)
+	}
+
+	if p.str("sortfield") == "name" {
+		sort.SliceStable(result, func(i, j int) bool { return result[i]["name"].(string) < result[j]["name"].(string) })
+	}
+	return result, nil
+}
+
+func (s *Server) maintenanceCreate(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Zabbix 6.0+ usa listas de objetos; versões anteriores, hostids e groupids
+	hostIDs := append(p.objectIDs("hosts", "hostid"), p.list("hostids")...)
+	groupIDs := append(p.objectIDs("groups", "groupid"), p.list("groupids")...)
+	if len(hostIDs) == 0 && len(groupIDs) == 0 {
+		return nil, &Error{Code: -32602, Message: "Invalid params.", Data: "At least one host group or host must be selected."}
+	}
+
+	m := &Maintenance{
+		ID:          s.newID(),
+		Name:        p.str("name"),
+		Description: p.str("description"),
+		ActiveSince: time.Unix(p.num("active_since"), 0),
+		ActiveTill:  time.Unix(p.num("active_till"), 0),
+		HostIDs:     hostIDs,
+		GroupIDs:    groupIDs,
+	}
+	for _, existing := range s.maintenances {
+		if existing.Name == m.Name {
+			return nil, &Error{Code: -32602, Message: "Invalid params.", Data: fmt.Sprintf("Maintenance \"%s\" already exists.", m.Name)}
+		}
+	}
+	s.maintenances = append(s.maintenances, m)
+	return object{"maintenanceids": []string{m.ID}}, nil
+}
+
+func (s *Server) maintenanceDelete(raw json.RawMessage) (interface{}, *Error) {
+	ids := rawList(raw)
+	for _, id := range ids {
+		found := false
+		for i, m := range s.maintenances {
+			if m.ID == id {
+				s.maintenances = append(s.maintenances[:i], s.maintenances[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, notFound("maintenance " + id)
+		}
+	}
+	return object{"maintenanceids": ids}, nil
+}
+
+func (s *Server) scriptGetByHosts(raw json.RawMessage) (interface{}, *Error) {
+	hostIDs := rawList(raw)
+
+	result := make(map[string][]object)
+	for _, id := range hostIDs {
+		if _, ok := s.host(id); !ok {
+			continue
+		}
+		scripts := []object{}
+		for _, sc := range s.scripts {
+			scripts = append(scripts, object{"scriptid": sc.ID, "name": sc.Name, "command": sc.Command, "description": sc.Description})
+		}
+		result[id] = scripts
+	}
+	return result, nil
+}
+
+func (s *Server) scriptExecute(raw json.RawMessage) (interface{}, *Error) {
+	p, err := decodeParams(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.host(p.str("hostid")); !ok {
+		return nil, notFound("host " + p.str("hostid"))
+	}
+	for _, sc := range s.scripts {
+		if sc.ID == p.str("scriptid") {
+			return object{"response": "success", "value": sc.Output}, nil
+		}
+	}
+	return nil, notFound("script " + p.str("scriptid"))
+}
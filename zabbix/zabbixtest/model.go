@@ -0,0 +1,243 @@
+package zabbixtest
+
+import (
+	"LapaTelegramBot/zabbix"
+	"time"
+)
+
+// Group é um grupo de hosts
+type Group struct {
+	ID   string
+	Name string
+}
+
+// Template é um template que pode ser vinculado aos hosts
+type Template struct {
+	ID   string
+	Host string
+	Name string
+}
+
+// Host é um host do modelo. IDs vazios são preenchidos ao adicionar.
+type Host struct {
+	ID   string
+	Host string
+	Name string
+	// Disabled corresponde a status=1 (monitoramento desativado)
+	Disabled bool
+	// Maintenance força maintenance_status=1; hosts cobertos por uma manutenção
+	// ativa também são informados em manutenção
+	Maintenance bool
+	GroupIDs    []string
+	TemplateIDs []string
+	Interfaces  []zabbix.Interface
+	Inventory   map[string]string
+	Tags        []zabbix.Tag
+}
+
+// Item é um item com último valor e histórico
+type Item struct {
+	zabbix.Item
+	// History guarda os valores usados por history.get e trend.get
+	History []zabbix.HistoryPoint
+}
+
+// Problem é um problema ativo, associado a uma trigger dos hosts informados
+type Problem struct {
+	EventID      string
+	TriggerID    string
+	Name         string
+	Severity     int
+	Clock        time.Time
+	Acknowledged bool
+	HostIDs      []string
+	// Messages são as mensagens enviadas por event.acknowledge
+	Messages []string
+}
+
+// Maintenance é um período de manutenção para hosts e grupos
+type Maintenance struct {
+	ID          string
+	Name        string
+	Description string
+	ActiveSince time.Time
+	ActiveTill  time.Time
+	HostIDs     []string
+	GroupIDs    []string
+}
+
+// Script é um script global disponível para todos os hosts
+type Script struct {
+	ID          string
+	Name        string
+	Command     string
+	Description string
+	// Output é a saída devolvida por script.execute
+	Output string
+}
+
+// AddGroup cria o grupo e devolve o seu ID
+func (s *Server) AddGroup(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g := &Group{ID: s.newID(), Name: name}
+	s.groups = append(s.groups, g)
+	return g.ID
+}
+
+// AddTemplate cria o template e devolve o seu ID
+func (s *Server) AddTemplate(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &Template{ID: s.newID(), Host: name, Name: name}
+	s.templates = append(s.templates, t)
+	return t.ID
+}
+
+// AddHost adiciona o host e devolve o seu ID. Interfaces sem ID recebem um novo ID.
+func (s *Server) AddHost(h Host) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addHost(h)
+}
+
+func (s *Server) addHost(h Host) string {
+	if h.ID == "" {
+		h.ID = s.newID()
+	}
+	if h.Name == "" {
+		h.Name = h.Host
+	}
+	for i := range h.Interfaces {
+		iface := &h.Interfaces[i]
+		if iface.Interfaceid == "" {
+			iface.Interfaceid = s.newID()
+		}
+		if iface.Main == "" {
+			iface.Main = "1"
+		}
+		if iface.UseIP == "" {
+			iface.UseIP = "1"
+		}
+		if iface.Available == "" {
+			iface.Available = "1"
+		}
+	}
+	s.hosts = append(s.hosts, &h)
+	return h.ID
+}
+
+// AddItem adiciona o item e devolve o seu ID
+func (s *Server) AddItem(item Item) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item.Itemid == "" {
+		item.Itemid = s.newID()
+	}
+	if item.ValueType == "" {
+		item.ValueType = zabbix.ValueUnsigned
+	}
+	s.items = append(s.items, &item)
+	return item.Itemid
+}
+
+// SetItemValue registra uma nova coleta: o último valor passa a ser o anterior
+func (s *Server) SetItemValue(itemID, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, item := range s.items {
+		if item.Itemid == itemID {
+			item.Prevvalue = item.Lastvalue
+			item.Lastvalue = value
+			item.Lastclock = unix(time.Now())
+		}
+	}
+}
+
+// AddProblem abre o problema e devolve o ID do evento
+func (s *Server) AddProblem(p Problem) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p.EventID == "" {
+		p.EventID = s.newID()
+	}
+	if p.TriggerID == "" {
+		p.TriggerID = s.newID()
+	}
+	if p.Clock.IsZero() {
+		p.Clock = time.Now()
+	}
+	s.problems = append(s.problems, &p)
+	return p.EventID
+}
+
+// ResolveProblem encerra o problema, que deixa de aparecer em problem.get
+func (s *Server) ResolveProblem(eventID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeProblem(eventID)
+}
+
+func (s *Server) removeProblem(eventID string) {
+	for i, p := range s.problems {
+		if p.EventID == eventID {
+			s.problems = append(s.problems[:i], s.problems[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddMaintenance cadastra a manutenção e devolve o seu ID
+func (s *Server) AddMaintenance(m Maintenance) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if m.ID == "" {
+		m.ID = s.newID()
+	}
+	s.maintenances = append(s.maintenances, &m)
+	return m.ID
+}
+
+// AddScript cadastra o script global e devolve o seu ID
+func (s *Server) AddScript(sc Script) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sc.ID == "" {
+		sc.ID = s.newID()
+	}
+	s.scripts = append(s.scripts, &sc)
+	return sc.ID
+}
+
+// Problems devolve uma cópia dos problemas ativos, para conferir o efeito de acks
+func (s *Server) Problems() []Problem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	problems := make([]Problem, 0, len(s.problems))
+	for _, p := range s.problems {
+		problems = append(problems, *p)
+	}
+	return problems
+}
+
+// Hosts devolve uma cópia dos hosts, para conferir o efeito de criações e alterações
+func (s *Server) Hosts() []Host {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosts := make([]Host, 0, len(s.hosts))
+	for _, h := range s.hosts {
+		hosts = append(hosts, *h)
+	}
+	return hosts
+}
+
+// Maintenances devolve uma cópia das manutenções cadastradas
+func (s *Server) Maintenances() []Maintenance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	maintenances := make([]Maintenance, 0, len(s.maintenances))
+	for _, m := range s.maintenances {
+		maintenances = append(maintenances, *m)
+	}
+	return maintenances
+}
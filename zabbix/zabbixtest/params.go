@@ -0,0 +1,191 @@
+package zabbixtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// params dá acesso aos parâmetros de um método, aceitando as variações de tipo
+// que a API do Zabbix aceita (ex: "hostids" como string ou lista, números como texto)
+type params map[string]json.RawMessage
+
+func decodeParams(raw json.RawMessage) (params, *Error) {
+	p := make(params)
+	if len(raw) == 0 || string(raw) == "null" {
+		return p, nil
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+	return p, nil
+}
+
+// has indica se o parâmetro foi enviado
+func (p params) has(key string) bool {
+	_, ok := p[key]
+	return ok
+}
+
+// str devolve o parâmetro como texto, aceitando números
+func (p params) str(key string) string {
+	return rawString(p[key])
+}
+
+// num devolve o parâmetro numérico, aceitando números enviados como texto
+func (p params) num(key string) int64 {
+	n, _ := strconv.ParseInt(p.str(key), 10, 64)
+	return n
+}
+
+// flag devolve o parâmetro booleano
+func (p params) flag(key string) bool {
+	var b bool
+	json.Unmarshal(p[key], &b)
+	return b
+}
+
+// list devolve o parâmetro como lista de textos, aceitando um valor único
+func (p params) list(key string) []string {
+	return rawList(p[key])
+}
+
+// objectIDs devolve o campo field de uma lista de objetos, ex: groups [{"groupid": "1"}]
+func (p params) objectIDs(key, field string) []string {
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(p[key], &objects); err != nil {
+		return nil
+	}
+	var ids []string
+	for _, o := range objects {
+		if id := rawString(o[field]); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// filter devolve os valores de "filter" para o campo informado; ok é falso sem filtro
+func (p params) filter(field string) (values []string, ok bool) {
+	var f map[string]json.RawMessage
+	if err := json.Unmarshal(p["filter"], &f); err != nil {
+		return nil, false
+	}
+	raw, ok := f[field]
+	if !ok {
+		return nil, false
+	}
+	return rawList(raw), true
+}
+
+// search devolve o valor de "search" para o campo informado
+func (p params) search(field string) string {
+	var s map[string]json.RawMessage
+	if err := json.Unmarshal(p["search"], &s); err != nil {
+		return ""
+	}
+	return rawString(s[field])
+}
+
+func rawString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	var b bool
+	if json.Unmarshal(raw, &b) == nil {
+		return strconv.FormatBool(b)
+	}
+	return ""
+}
+
+func rawList(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var items []json.RawMessage
+	if json.Unmarshal(raw, &items) != nil {
+		if s := rawString(raw); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, rawString(item))
+	}
+	return list
+}
+
+// matches indica se value está em values; lista vazia aceita qualquer valor
+func matches(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// contains indica se value está em values
+func contains(values []string, value string) bool {
+	return len(values) > 0 && matches(values, value)
+}
+
+// intersects indica se alguma das listas tem um valor em comum; want vazio aceita tudo
+func intersects(want, have []string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, h := range have {
+		if matches(want, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchMatch reproduz o "search" do Zabbix: trecho sem diferenciar maiúsculas e,
+// com searchWildcardsEnabled, "*" como curinga
+func searchMatch(value, pattern string, wildcards bool) bool {
+	if pattern == "" {
+		return true
+	}
+	if !wildcards {
+		return strings.Contains(strings.ToLower(value), strings.ToLower(pattern))
+	}
+
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	re, err := regexp.Compile("(?i)" + strings.Join(parts, ".*"))
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+func unix(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+func notFound(object string) *Error {
+	return &Error{Code: -32500, Message: "Application error.", Data: fmt.Sprintf("No permissions to referred object or it does not exist! (%s)", object)}
+}
@@ -0,0 +1,305 @@
+// Package zabbixtest fornece um servidor JSON-RPC do Zabbix em memória, baseado em
+// httptest, para testar os pacotes zabbix, monitor e telegram sem um Zabbix real e
+// para rodar o bot em modo demonstração (ver NewDemoServer).
+//
+// O modelo (hosts, grupos, itens, problemas, manutenções...) é programável pelos
+// métodos Add* e responde aos métodos da API usados pelo bot. Falhas e latência
+// podem ser injetadas por método com Fail, FailHTTP e SetLatency.
+package zabbixtest
+
+import (
+	"LapaTelegramBot/zabbix"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultToken é o token aceito pelo servidor criado com NewServer
+const DefaultToken = "zabbixtest-token"
+
+// AllMethods aplica Fail, FailHTTP e SetLatency a todos os métodos
+const AllMethods = "*"
+
+// Error é o objeto "error" devolvido pela API JSON-RPC
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+// ErrSessionTerminated simula uma sessão de user.login expirada
+var ErrSessionTerminated = Error{Code: -32602, Message: "Invalid params.", Data: "Session terminated, re-login, please."}
+
+// Server é o servidor fake. Os campos Version, Token, Username e Password podem ser
+// alterados antes das chamadas; o modelo deve ser alterado pelos métodos do Server.
+type Server struct {
+	*httptest.Server
+
+	// Version é a versão devolvida por apiinfo.version (padrão: 6.4.0)
+	Version string
+	// Token é o token de API aceito; sessões abertas por user.login também são aceitas
+	Token string
+	// Username e Password são as credenciais aceitas por user.login
+	Username string
+	Password string
+
+	mu           sync.Mutex
+	lastID       int
+	sessions     map[string]bool
+	groups       []*Group
+	templates    []*Template
+	hosts        []*Host
+	items        []*Item
+	problems     []*Problem
+	maintenances []*Maintenance
+	scripts      []*Script
+
+	failures  map[string]failure
+	latencies map[string]time.Duration
+	calls     map[string]int
+}
+
+// failure é uma falha injetada: erro JSON-RPC ou status HTTP
+type failure struct {
+	rpc    *Error
+	status int
+}
+
+// NewServer inicia um servidor vazio. Feche-o com Close ao terminar.
+func NewServer() *Server {
+	s := &Server{
+		Version:   "6.4.0",
+		Token:     DefaultToken,
+		Username:  "Admin",
+		Password:  "zabbix",
+		lastID:    10000,
+		sessions:  make(map[string]bool),
+		failures:  make(map[string]failure),
+		latencies: make(map[string]time.Duration),
+		calls:     make(map[string]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client cria um cliente zabbix apontado para o servidor, autenticado por token e
+// sem novas tentativas, para que as falhas injetadas apareçam na primeira chamada.
+func (s *Server) Client() *zabbix.Client {
+	c := zabbix.NewClient()
+	c.URL = s.URL + "/api_jsonrpc.php"
+	c.Token = s.Token
+	c.Username, c.Password = "", ""
+	c.MaxRetries = 0
+	return c
+}
+
+// Fail faz o método (ou AllMethods) responder com o erro JSON-RPC até ClearFailures
+func (s *Server) Fail(method string, err Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method] = failure{rpc: &err}
+}
+
+// FailHTTP faz o método (ou AllMethods) responder com o status HTTP até ClearFailures
+func (s *Server) FailHTTP(method string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method] = failure{status: status}
+}
+
+// SetLatency atrasa as respostas do método (ou AllMethods). Zero remove o atraso.
+func (s *Server) SetLatency(method string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d <= 0 {
+		delete(s.latencies, method)
+		return
+	}
+	s.latencies[method] = d
+}
+
+// ClearFailures remove todas as falhas e atrasos injetados
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = make(map[string]failure)
+	s.latencies = make(map[string]time.Duration)
+}
+
+// Calls devolve quantas vezes o método foi chamado
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// ExpireSessions encerra as sessões de user.login, forçando um novo login
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]bool)
+}
+
+type rpcRequest struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      int64           `json:"id"`
+	Auth    string          `json:"auth"`
+}
+
+type rpcResponse struct {
+	Jsonrpc string      `json:"jsonrpc"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   *Error      `json:"error,omitempty"`
+	ID      int64       `json:"id"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, rpcResponse{Jsonrpc: "2.0", Error: &Error{Code: -32700, Message: "Parse error.", Data: err.Error()}})
+		return
+	}
+
+	s.mu.Lock()
+	s.calls[req.Method]++
+	delay := s.latencies[req.Method] + s.latencies[AllMethods]
+	fail, failed := s.failures[req.Method]
+	if !failed {
+		fail, failed = s.failures[AllMethods]
+	}
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if failed && fail.status != 0 {
+		http.Error(w, http.StatusText(fail.status), fail.status)
+		return
+	}
+
+	resp := rpcResponse{Jsonrpc: "2.0", ID: req.ID}
+	if failed {
+		resp.Error = fail.rpc
+		writeJSON(w, resp)
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = req.Auth
+	}
+
+	result, rpcErr := s.dispatch(req.Method, req.Params, token)
+	if rpcErr != nil {
+		resp.Error = rpcErr
+	} else {
+		resp.Result = result
+	}
+	writeJSON(w, resp)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// method é a implementação de um método da API sobre o modelo, chamada com s.mu travado
+type method func(s *Server, params json.RawMessage) (interface{}, *Error)
+
+var methods = map[string]method{
+	"host.get":                 (*Server).hostGet,
+	"host.create":              (*Server).hostCreate,
+	"host.update":              (*Server).hostUpdate,
+	"host.massadd":             (*Server).hostMassAdd,
+	"hostgroup.get":            (*Server).hostgroupGet,
+	"hostinterface.get":        (*Server).hostinterfaceGet,
+	"hostinterface.create":     (*Server).hostinterfaceCreate,
+	"hostinterface.update":     (*Server).hostinterfaceUpdate,
+	"template.get":             (*Server).templateGet,
+	"item.get":                 (*Server).itemGet,
+	"history.get":              (*Server).historyGet,
+	"trend.get":                (*Server).trendGet,
+	"problem.get":              (*Server).problemGet,
+	"trigger.get":              (*Server).triggerGet,
+	"event.acknowledge":        (*Server).eventAcknowledge,
+	"maintenance.get":          (*Server).maintenanceGet,
+	"maintenance.create":       (*Server).maintenanceCreate,
+	"maintenance.delete":       (*Server).maintenanceDelete,
+	"script.getscriptsbyhosts": (*Server).scriptGetByHosts,
+	"script.execute":           (*Server).scriptExecute,
+}
+
+func (s *Server) dispatch(name string, params json.RawMessage, token string) (interface{}, *Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch name {
+	case "apiinfo.version":
+		return s.Version, nil
+	case "user.login":
+		return s.userLogin(params)
+	}
+
+	if token == "" || (token != s.Token && !s.sessions[token]) {
+		if strings.HasPrefix(token, "session-") {
+			return nil, &ErrSessionTerminated
+		}
+		return nil, &Error{Code: -32602, Message: "Invalid params.", Data: "Not authorized."}
+	}
+
+	m, ok := methods[name]
+	if !ok {
+		return nil, &Error{Code: -32601, Message: "Method not found.", Data: fmt.Sprintf("Incorrect API %q.", name)}
+	}
+	return m(s, params)
+}
+
+func (s *Server) userLogin(params json.RawMessage) (interface{}, *Error) {
+	var p struct {
+		User     string `json:"user"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	user := p.Username
+	if user == "" {
+		user = p.User
+	}
+	if user != s.Username || p.Password != s.Password {
+		return nil, &Error{Code: -32500, Message: "Application error.", Data: "Incorrect user name or password or account is temporarily blocked."}
+	}
+
+	session := "session-" + s.newID()
+	s.sessions[session] = true
+	return session, nil
+}
+
+// newID devolve um novo ID, único entre todos os objetos do modelo
+func (s *Server) newID() string {
+	s.lastID++
+	return strconv.Itoa(s.lastID)
+}
+
+func invalidParams(err error) *Error {
+	return &Error{Code: -32602, Message: "Invalid params.", Data: err.Error()}
+}